@@ -0,0 +1,106 @@
+// apperrors/apperrors.go
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel untuk jenis error domain. Gunakan errors.Is untuk memeriksanya;
+// middleware ErrorHandler memetakan setiap jenis ke HTTP status.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrValidation   = errors.New("validation failed")
+	ErrForbidden    = errors.New("forbidden")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrRateLimited  = errors.New("rate limited")
+
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+	ErrPreconditionFailed   = errors.New("precondition failed")
+	ErrUnprocessable        = errors.New("unprocessable")
+)
+
+// FieldError menjelaskan satu field yang tidak valid. Rule dan Param berasal
+// dari tag validasi (mis. "oneof" dan "pending completed") sehingga pesan
+// bisa disusun ulang, mis. diterjemahkan, tanpa membocorkan pesan validator.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"-"`
+	Message string `json:"message"`
+}
+
+// Error adalah error domain dengan kode stabil yang bisa dibaca mesin.
+// Code juga menjadi key katalog i18n; Params mengisi {0}, {1}, dst. di
+// pesan terjemahan, sedangkan Message adalah pesan bahasa Inggris cadangan.
+type Error struct {
+	Kind    error
+	Code    string
+	Message string
+	Params  []string
+	Fields  []FieldError
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Err != nil {
+		return []error{e.Kind, e.Err}
+	}
+	return []error{e.Kind}
+}
+
+// WithParams menyimpan parameter untuk pesan terjemahan.
+func (e *Error) WithParams(params ...string) *Error {
+	e.Params = params
+	return e
+}
+
+// Wrap menyimpan penyebab asli untuk log tanpa mengubah pesan ke client.
+func (e *Error) Wrap(err error) *Error {
+	e.Err = err
+	return e
+}
+
+func NotFound(code, message string) *Error {
+	return &Error{Kind: ErrNotFound, Code: code, Message: message}
+}
+
+func Conflict(code, message string) *Error {
+	return &Error{Kind: ErrConflict, Code: code, Message: message}
+}
+
+func Validation(code, message string, fields ...FieldError) *Error {
+	return &Error{Kind: ErrValidation, Code: code, Message: message, Fields: fields}
+}
+
+func Forbidden(code, message string) *Error {
+	return &Error{Kind: ErrForbidden, Code: code, Message: message}
+}
+
+func Unauthorized(code, message string) *Error {
+	return &Error{Kind: ErrUnauthorized, Code: code, Message: message}
+}
+
+func RateLimited(code, message string) *Error {
+	return &Error{Kind: ErrRateLimited, Code: code, Message: message}
+}
+
+func UnsupportedMediaType(code, message string) *Error {
+	return &Error{Kind: ErrUnsupportedMediaType, Code: code, Message: message}
+}
+
+func PreconditionFailed(code, message string) *Error {
+	return &Error{Kind: ErrPreconditionFailed, Code: code, Message: message}
+}
+
+func Unprocessable(code, message string) *Error {
+	return &Error{Kind: ErrUnprocessable, Code: code, Message: message}
+}
@@ -0,0 +1,83 @@
+// cmd/commands.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/config"
+)
+
+const commandsUsage = `Commands:
+  config print [flags]                 show effective configuration, secrets redacted
+  secrets keygen                       generate a base64 key for SECRETS_KEY
+  secrets encrypt <plain.json> <out>   encrypt a {"ENV_NAME": "value"} file with SECRETS_KEY
+`
+
+// runCommand menjalankan subcommand CLI. handled bernilai false jika args
+// bukan subcommand sehingga main lanjut menjalankan server.
+func runCommand(args []string) (handled bool, code int) {
+	if len(args) < 2 {
+		return false, 0
+	}
+
+	switch args[0] + " " + args[1] {
+	case "config print":
+		cfg, err := config.Load(args[2:])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid configuration:\n%v\n", err)
+		}
+		config.Print(os.Stdout, cfg)
+		if err != nil {
+			return true, 1
+		}
+		return true, 0
+
+	case "secrets keygen":
+		key, err := config.GenerateSecretsKey()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return true, 1
+		}
+		fmt.Println(key)
+		return true, 0
+
+	case "secrets encrypt":
+		if len(args) != 4 {
+			fmt.Fprint(os.Stderr, commandsUsage)
+			return true, 2
+		}
+		if err := encryptSecrets(args[2], args[3]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return true, 1
+		}
+		return true, 0
+	}
+
+	return false, 0
+}
+
+func encryptSecrets(in, out string) error {
+	key, err := config.DecodeSecretsKey(os.Getenv("SECRETS_KEY"))
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(in)
+	if err != nil {
+		return err
+	}
+	values := make(map[string]string)
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("%s: expected a JSON object of strings: %w", in, err)
+	}
+
+	f, err := os.OpenFile(out, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return config.EncryptSecrets(f, values, key)
+}
@@ -2,50 +2,94 @@
 package main
 
 import (
-	"database/sql"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"net/http"
+	"net/url"
 	"os"
+	"os/signal"
+	"syscall"
 
-	"github.com/joho/godotenv"
+	"github.com/XSAM/otelsql"
 	"github.com/gin-gonic/gin"
+	_ "github.com/godror/godror" // Oracle driver
+	_ "github.com/lib/pq"        // PostgreSQL driver
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
 	"github.com/programmercintasunnah/go-todolist-ilcs/config"
 	"github.com/programmercintasunnah/go-todolist-ilcs/controllers"
 	"github.com/programmercintasunnah/go-todolist-ilcs/middlewares"
+	"github.com/programmercintasunnah/go-todolist-ilcs/migrations"
 	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
 	"github.com/programmercintasunnah/go-todolist-ilcs/services"
+	"github.com/programmercintasunnah/go-todolist-ilcs/tracing"
 	"github.com/sirupsen/logrus"
-	_ "github.com/lib/pq"         // PostgreSQL driver
-	_ "github.com/godror/godror"  // Oracle driver
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
 func main() {
-	if err := godotenv.Load(); err != nil {
-        log.Fatal("Error loading .env file")
-    }
-	
-	cfg := config.LoadConfig()
+	// Subcommand seperti `config print` dan `secrets encrypt`
+	args := os.Args[1:]
+	if handled, code := runCommand(args); handled {
+		os.Exit(code)
+	}
+
+	cfg, err := config.Load(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("Invalid configuration:\n%v", err)
+	}
 
 	// Setup Logging
 	logger := logrus.New()
 	logger.SetFormatter(&logrus.JSONFormatter{})
 
+	// Setup Config Reload (SIGHUP atau perubahan file konfigurasi)
+	watcher := config.NewWatcher(cfg, func() (config.Config, error) { return config.Load(args) }, logger)
+	watcher.Subscribe(func(cfg config.Config) {
+		level, _ := logrus.ParseLevel(cfg.Log.Level)
+		logger.SetLevel(level)
+	})
+	watchCtx, stopWatching := context.WithCancel(context.Background())
+	var watchPaths []string
+	if cfg.File != "" {
+		watchPaths = append(watchPaths, cfg.File)
+	}
+	go watcher.Run(watchCtx, cfg.App.ReloadInterval, watchPaths...)
+
+	// Setup Tracing
+	shutdownTracing, err := tracing.Init(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+
 	// Setup Database Connection
 	var dsn string
-	switch os.Getenv("DB_TYPE") {
+	var driver = cfg.Database.Type
+	var dbSystem = semconv.DBSystemPostgreSQL
+	switch cfg.Database.Type {
 	case "postgres":
-		dsn = fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable", cfg.DBUser, cfg.DBPassword, cfg.DBHost, cfg.DBPort, cfg.DBName)
+		dsn = fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable&options=%s", cfg.Database.User, cfg.Database.Password, cfg.Database.Host, cfg.Database.Port, cfg.Database.Name,
+			url.QueryEscape(repositories.PostgresOptions()))
 	case "oracle":
-		dsn = fmt.Sprintf("oracle://%s:%s@%s:%s/%s", cfg.DBUser, cfg.DBPassword, cfg.DBHost, cfg.DBPort, cfg.DBName)
-	default:
-		log.Fatal("Unsupported DB_TYPE in .env file")
+		dsn = fmt.Sprintf("oracle://%s:%s@%s:%d/%s", cfg.Database.User, cfg.Database.Password, cfg.Database.Host, cfg.Database.Port, cfg.Database.Name)
+		driver = "godror"
+		dbSystem = semconv.DBSystemOracle
 	}
 
-	db, err := sql.Open(os.Getenv("DB_TYPE"), dsn)
+	// otelsql membuat span untuk setiap statement SQL
+	db, err := otelsql.Open(driver, dsn, otelsql.WithAttributes(dbSystem))
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
-	defer db.Close()
+	db.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
 
 	// Check connection
 	err = db.Ping()
@@ -53,38 +97,162 @@ func main() {
 		log.Fatalf("Failed to ping database: %v", err)
 	}
 
+	// Jalankan migrasi skema
+	if err := migrations.Up(context.Background(), db, cfg.Database.Type); err != nil {
+		log.Fatalf("Failed to run migrations: %v", err)
+	}
+
 	// Initialize Repositories, Services, Controllers
-	redisClient := repositories.InitRedis(cfg)
-	taskRepo := repositories.NewTaskRepository(db, redisClient, logger)
-	taskService := services.NewTaskService(taskRepo, logger)
-	taskController := controllers.NewTaskController(taskService, logger)
+	redisClient := repositories.InitRedis(cfg.Redis)
+	taskRepo := repositories.NewTaskRepository(db, repositories.Dialect(cfg.Database.Type), redisClient, watcher, logger)
+	unitOfWork := repositories.NewUnitOfWork(db, repositories.Repositories{Tasks: taskRepo})
+	taskService := services.NewTaskService(taskRepo, unitOfWork, watcher, logger)
+	cursorSecret := cfg.Pagination.CursorSecret
+	if cursorSecret == "" {
+		cursorSecret = cfg.JWT.Secret
+	}
+	viewService := services.NewViewService(repositories.NewViewRepository(db, repositories.Dialect(cfg.Database.Type)))
+	taskController := controllers.NewTaskController(taskService, viewService, cursorSecret, logger)
+	viewController := controllers.NewViewController(viewService, logger)
+	tagController := controllers.NewTagController(services.NewTagService(repositories.NewTagRepository(taskRepo)), logger)
+	checklistController := controllers.NewChecklistController(services.NewChecklistService(repositories.NewChecklistRepository(taskRepo)), logger)
+	dependencyController := controllers.NewDependencyController(services.NewDependencyService(repositories.NewDependencyRepository(taskRepo)), logger)
+	projectController := controllers.NewProjectController(services.NewProjectService(repositories.NewProjectRepository(taskRepo)), logger)
+	healthController := controllers.NewHealthController(db, redisClient, logger)
+	authController := controllers.NewAuthController(cfg.JWT.Secret, cfg.JWT.TokenTTL)
 
 	// Setup Gin
 	router := gin.New()
 
 	// Apply Middlewares
 	router.Use(gin.Recovery())
+	router.Use(otelgin.Middleware(cfg.Tracing.ServiceName, otelgin.WithFilter(func(r *http.Request) bool {
+		// Probe dipanggil setiap beberapa detik, tidak perlu di-trace
+		return r.URL.Path != "/healthz" && r.URL.Path != "/readyz"
+	})))
 	router.Use(middlewares.Logger(logger))
+	router.Use(middlewares.Locale())
+	router.Use(middlewares.ErrorHandler(logger))
+	router.NoRoute(func(c *gin.Context) {
+		c.Error(apperrors.NotFound("route_not_found", "No route matches "+c.Request.Method+" "+c.Request.URL.Path).
+			WithParams(c.Request.Method, c.Request.URL.Path))
+	})
+
+	// Health Routes
+	router.GET("/healthz", healthController.Liveness)
+	router.GET("/readyz", healthController.Readiness)
+
+	rateLimit := middlewares.RateLimit(watcher)
 
 	// Public Routes
 	public := router.Group("/api")
+	public.Use(rateLimit)
 	{
-		public.POST("/login", controllers.Login)
+		public.POST("/login", authController.Login)
 	}
 
 	// Protected Routes
 	protected := router.Group("/api")
-	protected.Use(middlewares.JWTAuth(cfg.JWTSecret))
+	protected.Use(
+		middlewares.JWTAuth(cfg.JWT.Secret),
+		rateLimit,
+		middlewares.Idempotency(repositories.NewIdempotencyStore(redisClient), watcher, logger),
+	)
 	{
 		protected.POST("/tasks", taskController.CreateTask)
+		protected.POST("/tasks/bulk", taskController.BulkTasks)
+		protected.POST("/tasks/bulk/update", taskController.UpdateTasksByFilter)
+		protected.POST("/tasks/bulk/delete", taskController.DeleteTasksByFilter)
 		protected.GET("/tasks", taskController.GetAllTasks)
+		protected.GET("/tasks/suggest", taskController.SuggestTitles)
 		protected.GET("/tasks/:id", taskController.GetTaskByID)
 		protected.PUT("/tasks/:id", taskController.UpdateTask)
+		protected.PATCH("/tasks/:id", taskController.PatchTask)
 		protected.DELETE("/tasks/:id", taskController.DeleteTask)
+		protected.POST("/tasks/:id/tags", tagController.AttachTags)
+		protected.DELETE("/tasks/:id/tags/:tag_id", tagController.DetachTag)
+		protected.PUT("/tasks/:id/project", projectController.MoveTask)
+		protected.GET("/tasks/:id/subtasks", taskController.GetSubtasks)
+		protected.PUT("/tasks/:id/parent", taskController.SetParent)
+		protected.POST("/tasks/:id/checklist", checklistController.AddItem)
+		protected.PUT("/tasks/:id/checklist/:item_id", checklistController.UpdateItem)
+		protected.DELETE("/tasks/:id/checklist/:item_id", checklistController.DeleteItem)
+		protected.GET("/tasks/:id/dependencies", dependencyController.GetGraph)
+		protected.POST("/tasks/:id/dependencies", dependencyController.LinkTasks)
+		protected.DELETE("/tasks/:id/dependencies/:blocker_id", dependencyController.UnlinkTasks)
+		protected.GET("/tags", tagController.ListTags)
+		protected.POST("/tags", tagController.CreateTag)
+		protected.GET("/tags/:id", tagController.GetTag)
+		protected.PUT("/tags/:id", tagController.RenameTag)
+		protected.DELETE("/tags/:id", tagController.DeleteTag)
+		protected.POST("/tags/:id/merge", tagController.MergeTags)
+		protected.GET("/projects", projectController.ListProjects)
+		protected.POST("/projects", projectController.CreateProject)
+		protected.GET("/projects/:id", projectController.GetProject)
+		protected.PUT("/projects/:id", projectController.UpdateProject)
+		protected.DELETE("/projects/:id", projectController.DeleteProject)
+		protected.PUT("/projects/:id/archive", projectController.ArchiveProject)
+		protected.DELETE("/projects/:id/archive", projectController.UnarchiveProject)
+		protected.GET("/projects/:id/tasks", projectController.RequireProject, taskController.GetAllTasks)
+		protected.POST("/projects/:id/tasks", projectController.RequireProject, taskController.CreateTask)
+		protected.POST("/views", viewController.CreateView)
+		protected.GET("/views", viewController.ListViews)
+		protected.GET("/views/:id", viewController.GetView)
+		protected.PUT("/views/:id", viewController.UpdateView)
+		protected.DELETE("/views/:id", viewController.DeleteView)
+		protected.PUT("/views/:id/default", viewController.SetDefaultView)
+		protected.DELETE("/views/:id/default", viewController.ClearDefaultView)
 	}
 
 	// Start Server
-	if err := router.Run(":8080"); err != nil {
-		logger.Fatal("Failed to run server: ", err)
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.Server.Port),
+		Handler:           router,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		ReadHeaderTimeout: cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+		MaxHeaderBytes:    cfg.Server.MaxHeaderBytes,
 	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		logger.Infof("Server listening on %s", srv.Addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+	}()
+
+	select {
+	case err := <-serverErr:
+		logger.Error("Failed to run server: ", err)
+	case <-ctx.Done():
+		logger.Info("Shutdown signal received, draining in-flight requests")
+	}
+	// Sinyal kedua langsung menghentikan proses
+	stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	// Urutan penting: berhenti menerima request dan tunggu request yang berjalan,
+	// baru tutup dependency yang dipakai oleh request tersebut.
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Server did not drain before deadline: ", err)
+	}
+	stopWatching()
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Error("Failed to flush traces: ", err)
+	}
+	if err := redisClient.Close(); err != nil {
+		logger.Error("Failed to close Redis client: ", err)
+	}
+	if err := db.Close(); err != nil {
+		logger.Error("Failed to close database: ", err)
+	}
+
+	logger.Info("Server stopped")
 }
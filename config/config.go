@@ -2,37 +2,186 @@
 package config
 
 import (
-	"os"
+	"time"
 )
 
+// Config adalah konfigurasi efektif aplikasi. Nilai diisi berlapis:
+// default < file YAML/TOML < environment variable < secret provider < flag CLI.
+//
+// Setiap field daun punya tag `key` (nama di file, digabung dengan
+// section-nya, mis. "server.port"), `env` (environment variable) dan
+// opsional `secret:"true"` agar nilainya disamarkan saat dicetak serta
+// `reload:"true"` untuk field yang boleh berubah tanpa restart (lihat Watcher).
+// Nama flag diturunkan dari key, mis. -server-port.
 type Config struct {
-	DBType      string
-	DBUser      string
-	DBPassword  string
-	DBHost      string
-	DBPort      string
-	DBName      string
+	App       AppConfig       `key:"app"`
+	Server    ServerConfig    `key:"server"`
+	Database  DatabaseConfig  `key:"database"`
+	Redis     RedisConfig     `key:"redis"`
+	JWT       JWTConfig       `key:"jwt"`
+	Tracing   TracingConfig   `key:"tracing"`
+	Log       LogConfig       `key:"log"`
+	RateLimit RateLimitConfig `key:"rate_limit"`
+	Cache     CacheConfig     `key:"cache"`
+	Features  FeaturesConfig  `key:"features"`
 
-	RedisAddr     string
-	RedisPassword string
-	RedisDB       int
+	Idempotency IdempotencyConfig `key:"idempotency"`
+	Pagination  PaginationConfig  `key:"pagination"`
+	Tasks       TasksConfig       `key:"tasks"`
 
-	JWTSecret string
+	// File adalah path file konfigurasi yang dipakai Load, kosong jika tidak ada.
+	File string
 }
 
-func LoadConfig() Config {
+type AppConfig struct {
+	// Env adalah development, staging atau production. Di production
+	// validasi lebih ketat, mis. JWT secret tidak boleh nilai bawaan.
+	Env string `key:"env" env:"APP_ENV"`
+	// ReloadInterval adalah interval cek perubahan file konfigurasi; 0 berarti
+	// hanya reload saat SIGHUP.
+	ReloadInterval time.Duration `key:"reload_interval" env:"CONFIG_RELOAD_INTERVAL"`
+}
+
+type ServerConfig struct {
+	Port            int           `key:"port" env:"SERVER_PORT"`
+	ReadTimeout     time.Duration `key:"read_timeout" env:"SERVER_READ_TIMEOUT"`
+	WriteTimeout    time.Duration `key:"write_timeout" env:"SERVER_WRITE_TIMEOUT"`
+	IdleTimeout     time.Duration `key:"idle_timeout" env:"SERVER_IDLE_TIMEOUT"`
+	MaxHeaderBytes  int           `key:"max_header_bytes" env:"SERVER_MAX_HEADER_BYTES"`
+	ShutdownTimeout time.Duration `key:"shutdown_timeout" env:"SERVER_SHUTDOWN_TIMEOUT"`
+}
+
+type DatabaseConfig struct {
+	Type            string        `key:"type" env:"DB_TYPE"`
+	User            string        `key:"user" env:"DB_USER"`
+	Password        string        `key:"password" env:"DB_PASSWORD" secret:"true"`
+	Host            string        `key:"host" env:"DB_HOST"`
+	Port            int           `key:"port" env:"DB_PORT"`
+	Name            string        `key:"name" env:"DB_NAME"`
+	MaxOpenConns    int           `key:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `key:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `key:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME"`
+}
+
+type RedisConfig struct {
+	Addr     string `key:"addr" env:"REDIS_ADDR"`
+	Password string `key:"password" env:"REDIS_PASSWORD" secret:"true"`
+	DB       int    `key:"db" env:"REDIS_DB"`
+	PoolSize int    `key:"pool_size" env:"REDIS_POOL_SIZE"`
+}
+
+type JWTConfig struct {
+	Secret   string        `key:"secret" env:"JWT_SECRET" secret:"true"`
+	TokenTTL time.Duration `key:"token_ttl" env:"JWT_TOKEN_TTL"`
+}
+
+type TracingConfig struct {
+	ServiceName  string  `key:"service_name" env:"OTEL_SERVICE_NAME"`
+	Exporter     string  `key:"exporter" env:"OTEL_TRACES_EXPORTER"`
+	OTLPEndpoint string  `key:"otlp_endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	SampleRatio  float64 `key:"sample_ratio" env:"OTEL_TRACES_SAMPLER_ARG"`
+}
+
+type LogConfig struct {
+	Level string `key:"level" env:"LOG_LEVEL" reload:"true"`
+}
+
+type RateLimitConfig struct {
+	// RequestsPerSecond per client (username atau IP); 0 mematikan rate limit.
+	RequestsPerSecond float64 `key:"requests_per_second" env:"RATE_LIMIT_RPS" reload:"true"`
+	Burst             int     `key:"burst" env:"RATE_LIMIT_BURST" reload:"true"`
+}
+
+type CacheConfig struct {
+	TaskTTL time.Duration `key:"task_ttl" env:"CACHE_TASK_TTL" reload:"true"`
+}
+
+type FeaturesConfig struct {
+	Enabled []string `key:"enabled" env:"FEATURE_FLAGS" reload:"true"`
+}
+
+type IdempotencyConfig struct {
+	// TTL adalah lama respons disimpan untuk diputar ulang per Idempotency-Key.
+	TTL time.Duration `key:"ttl" env:"IDEMPOTENCY_TTL" reload:"true"`
+}
+
+type PaginationConfig struct {
+	// CursorSecret menandatangani token cursor pada GET /api/tasks; kosong
+	// berarti memakai jwt.secret. Mengganti nilainya membuat cursor lama
+	// tidak berlaku.
+	CursorSecret string `key:"cursor_secret" env:"CURSOR_SECRET" secret:"true"`
+}
+
+type TasksConfig struct {
+	// MaxSubtaskDepth membatasi kedalaman subtask; task tingkat atas
+	// berkedalaman 0, subtask-nya 1, dan seterusnya.
+	MaxSubtaskDepth int `key:"max_subtask_depth" env:"TASKS_MAX_SUBTASK_DEPTH" reload:"true"`
+	// EnforceDependencies menolak menyelesaikan task yang masih diblokir
+	// task lain yang belum completed.
+	EnforceDependencies bool `key:"enforce_dependencies" env:"TASKS_ENFORCE_DEPENDENCIES" reload:"true"`
+}
+
+// IsEnabled melaporkan apakah feature flag name aktif.
+func (f FeaturesConfig) IsEnabled(name string) bool {
+	for _, enabled := range f.Enabled {
+		if enabled == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Default mengembalikan nilai bawaan sebelum lapisan lain diterapkan.
+func Default() Config {
 	return Config{
-		DBType:      os.Getenv("DB_TYPE"),
-		DBUser:      os.Getenv("DB_USER"),
-		DBPassword:  os.Getenv("DB_PASSWORD"),
-		DBHost:      os.Getenv("DB_HOST"),
-		DBPort:      os.Getenv("DB_PORT"),
-		DBName:      os.Getenv("DB_NAME"),
-
-		RedisAddr:     os.Getenv("REDIS_ADDR"),
-		RedisPassword: os.Getenv("REDIS_PASSWORD"),
-		RedisDB:       0,
-
-		JWTSecret: os.Getenv("JWT_SECRET"),
+		App: AppConfig{
+			Env:            "development",
+			ReloadInterval: 5 * time.Second,
+		},
+		Server: ServerConfig{
+			Port:            8080,
+			ReadTimeout:     15 * time.Second,
+			WriteTimeout:    15 * time.Second,
+			IdleTimeout:     60 * time.Second,
+			MaxHeaderBytes:  1 << 20,
+			ShutdownTimeout: 30 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Type:            "postgres",
+			Host:            "localhost",
+			Port:            5432,
+			MaxOpenConns:    25,
+			MaxIdleConns:    25,
+			ConnMaxLifetime: 5 * time.Minute,
+		},
+		Redis: RedisConfig{
+			Addr:     "localhost:6379",
+			PoolSize: 10,
+		},
+		JWT: JWTConfig{
+			TokenTTL: 72 * time.Hour,
+		},
+		Tracing: TracingConfig{
+			ServiceName: "go-todolist-ilcs",
+			Exporter:    "none",
+			SampleRatio: 1,
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+		RateLimit: RateLimitConfig{
+			RequestsPerSecond: 0,
+			Burst:             20,
+		},
+		Cache: CacheConfig{
+			TaskTTL: 10 * time.Minute,
+		},
+		Idempotency: IdempotencyConfig{
+			TTL: 24 * time.Hour,
+		},
+		Tasks: TasksConfig{
+			MaxSubtaskDepth:     3,
+			EnforceDependencies: true,
+		},
 	}
 }
@@ -0,0 +1,276 @@
+// config/loader.go
+package config
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Load membangun Config dari default, file (-config atau CONFIG_FILE),
+// environment variable (termasuk .env jika ada), secret provider lalu flag
+// di args. Field bertanda secret dicari di <KEY>_FILE, file terenkripsi
+// (SECRETS_FILE) lalu providers, dan provider pertama yang punya nilai menang.
+// Semua kesalahan parsing dan validasi dikumpulkan menjadi satu error.
+func Load(args []string, providers ...SecretProvider) (Config, error) {
+	// .env bersifat opsional; variabel yang sudah diset di environment tidak ditimpa
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Config{}, fmt.Errorf("load .env: %w", err)
+	}
+
+	cfg := Default()
+	fields := cfg.fields()
+
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML or TOML config file (env CONFIG_FILE)")
+	overrides := make(map[string]string)
+	for _, f := range fields {
+		key := f.Key
+		fs.Func(f.flagName(), fmt.Sprintf("%s (env %s)", f.Key, f.Env), func(s string) error {
+			overrides[key] = s
+			return nil
+		})
+	}
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+
+	var errs []error
+
+	cfg.File = *configFile
+	if *configFile != "" {
+		values, err := readFile(*configFile)
+		if err != nil {
+			return Config{}, err
+		}
+		for _, f := range fields {
+			raw, ok := values[f.Key]
+			if !ok {
+				continue
+			}
+			delete(values, f.Key)
+			if list, ok := raw.([]interface{}); ok {
+				items := make([]string, len(list))
+				for i, item := range list {
+					items[i] = fmt.Sprint(item)
+				}
+				raw = strings.Join(items, ",")
+			}
+			if err := f.set(fmt.Sprint(raw)); err != nil {
+				errs = append(errs, fmt.Errorf("%s (file %s): %w", f.Key, *configFile, err))
+			}
+		}
+		for key := range values {
+			errs = append(errs, fmt.Errorf("%s (file %s): unknown key", key, *configFile))
+		}
+	}
+
+	for _, f := range fields {
+		// Nilai kosong dianggap tidak diset agar default tetap berlaku
+		raw := strings.TrimSpace(os.Getenv(f.Env))
+		if f.Env == "" || raw == "" {
+			continue
+		}
+		if err := f.set(raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s (env %s): %w", f.Key, f.Env, err))
+		}
+	}
+
+	defaults, err := defaultSecretProviders(context.Background())
+	if err != nil {
+		return Config{}, err
+	}
+	providers = append(defaults, providers...)
+
+	for _, f := range fields {
+		if !f.Secret {
+			continue
+		}
+		for _, p := range providers {
+			raw, ok, err := p.Lookup(context.Background(), f.Env)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s (secret provider %s): %w", f.Key, p.Name(), err))
+				break
+			}
+			if ok {
+				if err := f.set(raw); err != nil {
+					errs = append(errs, fmt.Errorf("%s (secret provider %s): %w", f.Key, p.Name(), err))
+				}
+				break
+			}
+		}
+	}
+
+	for _, f := range fields {
+		raw, ok := overrides[f.Key]
+		if !ok {
+			continue
+		}
+		if err := f.set(raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s (flag -%s): %w", f.Key, f.flagName(), err))
+		}
+	}
+
+	if len(errs) == 0 {
+		if err := cfg.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return cfg, errors.Join(errs...)
+}
+
+// field adalah satu nilai konfigurasi daun di dalam Config.
+type field struct {
+	Key        string
+	Env        string
+	Secret     bool
+	Reloadable bool
+	value      reflect.Value
+}
+
+func (c *Config) fields() []field {
+	var out []field
+	walkFields(reflect.ValueOf(c).Elem(), "", &out)
+	return out
+}
+
+func walkFields(v reflect.Value, prefix string, out *[]field) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		key := sf.Tag.Get("key")
+		if key == "" {
+			continue
+		}
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if sf.Type.Kind() == reflect.Struct {
+			walkFields(v.Field(i), key, out)
+			continue
+		}
+
+		*out = append(*out, field{
+			Key:        key,
+			Env:        sf.Tag.Get("env"),
+			Secret:     sf.Tag.Get("secret") == "true",
+			Reloadable: sf.Tag.Get("reload") == "true",
+			value:      v.Field(i),
+		})
+	}
+}
+
+func (f field) flagName() string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(f.Key)
+}
+
+func (f field) set(raw string) error {
+	if f.value.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", raw)
+		}
+		f.value.SetInt(int64(d))
+		return nil
+	}
+
+	switch f.value.Kind() {
+	case reflect.String:
+		f.value.SetString(raw)
+	case reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		f.value.SetInt(int64(n))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		f.value.SetBool(b)
+	case reflect.Slice:
+		// Daftar ditulis dipisah koma, mis. FEATURE_FLAGS=a,b
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		f.value.Set(reflect.ValueOf(items))
+	case reflect.Float64:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		f.value.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported config type %s", f.value.Type())
+	}
+	return nil
+}
+
+func (f field) String() string {
+	if f.value.Type() == durationType {
+		return time.Duration(f.value.Int()).String()
+	}
+	if items, ok := f.value.Interface().([]string); ok {
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(f.value.Interface())
+}
+
+// readFile membaca file YAML/TOML dan meratakannya menjadi key bertitik,
+// mis. {"server": {"port": 8080}} menjadi {"server.port": 8080}.
+func readFile(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+
+	raw := make(map[string]interface{})
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	default:
+		return nil, fmt.Errorf("config file %s: unsupported extension, use .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+
+	values := make(map[string]interface{})
+	flatten("", raw, values)
+	return values, nil
+}
+
+func flatten(prefix string, in map[string]interface{}, out map[string]interface{}) {
+	for k, v := range in {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if nested, ok := v.(map[string]interface{}); ok {
+			flatten(key, nested, out)
+			continue
+		}
+		out[key] = v
+	}
+}
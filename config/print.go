@@ -0,0 +1,25 @@
+// config/print.go
+package config
+
+import (
+	"fmt"
+	"io"
+)
+
+const redacted = "<redacted>"
+
+// Print menulis nilai efektif setiap key beserta environment variable-nya.
+// Field bertanda secret disamarkan; secret kosong tetap ditampilkan kosong
+// supaya terlihat bahwa nilainya belum diset.
+func Print(w io.Writer, cfg Config) error {
+	for _, f := range cfg.fields() {
+		value := f.String()
+		if f.Secret && value != "" {
+			value = redacted
+		}
+		if _, err := fmt.Fprintf(w, "%-32s = %-24s # %s\n", f.Key, value, f.Env); err != nil {
+			return err
+		}
+	}
+	return nil
+}
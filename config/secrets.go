@@ -0,0 +1,211 @@
+// config/secrets.go
+package config
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// SecretProvider mengambil nilai secret berdasarkan nama environment
+// variable-nya (mis. "DB_PASSWORD"). Implementasi lain seperti Vault atau
+// AWS Secrets Manager cukup memenuhi interface ini lalu diberikan ke Load.
+type SecretProvider interface {
+	Name() string
+	Lookup(ctx context.Context, key string) (value string, ok bool, err error)
+}
+
+// FileEnvProvider membaca secret dari file yang ditunjuk oleh <KEY>_FILE,
+// sesuai konvensi Docker/Kubernetes secrets (mis. DB_PASSWORD_FILE=/run/secrets/db).
+type FileEnvProvider struct{}
+
+func (FileEnvProvider) Name() string { return "file-env" }
+
+func (FileEnvProvider) Lookup(_ context.Context, key string) (string, bool, error) {
+	path := strings.TrimSpace(os.Getenv(key + "_FILE"))
+	if path == "" {
+		return "", false, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("read %s_FILE: %w", key, err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), true, nil
+}
+
+// encryptedFile adalah format file secrets terenkripsi: JSON berisi nonce dan
+// ciphertext AES-256-GCM dari sebuah objek JSON {"ENV_NAME": "value"}.
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	Nonce      string `json:"nonce"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+// EncryptedFileProvider membaca secret dari file lokal terenkripsi.
+type EncryptedFileProvider struct {
+	values map[string]string
+}
+
+// NewEncryptedFileProvider mendekripsi file di path dengan key 32 byte
+// (base64) dan menyimpan isinya di memori.
+func NewEncryptedFileProvider(path string, key []byte) (*EncryptedFileProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read secrets file: %w", err)
+	}
+
+	var file encryptedFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse secrets file: %w", err)
+	}
+	if file.Version != 1 {
+		return nil, fmt.Errorf("secrets file: unsupported version %d", file.Version)
+	}
+
+	nonce, err := base64.StdEncoding.DecodeString(file.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("secrets file: invalid nonce: %w", err)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(file.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("secrets file: invalid ciphertext: %w", err)
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, errors.New("secrets file: invalid nonce size")
+	}
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("secrets file: decryption failed, wrong key or corrupted file")
+	}
+
+	values := make(map[string]string)
+	if err := json.Unmarshal(plaintext, &values); err != nil {
+		return nil, fmt.Errorf("secrets file: invalid content: %w", err)
+	}
+	return &EncryptedFileProvider{values: values}, nil
+}
+
+func (p *EncryptedFileProvider) Name() string { return "encrypted-file" }
+
+func (p *EncryptedFileProvider) Lookup(_ context.Context, key string) (string, bool, error) {
+	v, ok := p.values[key]
+	return v, ok, nil
+}
+
+// EncryptSecrets mengenkripsi values dengan key dan menulis hasilnya ke w
+// dalam format yang dibaca oleh NewEncryptedFileProvider.
+func EncryptSecrets(w io.Writer, values map[string]string, key []byte) error {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(encryptedFile{
+		Version:    1,
+		Nonce:      base64.StdEncoding.EncodeToString(nonce),
+		Ciphertext: base64.StdEncoding.EncodeToString(gcm.Seal(nil, nonce, plaintext, nil)),
+	})
+}
+
+// GenerateSecretsKey membuat key acak 32 byte dalam bentuk base64 untuk SECRETS_KEY.
+func GenerateSecretsKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// DecodeSecretsKey mengubah SECRETS_KEY (base64) menjadi key AES-256.
+func DecodeSecretsKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("secrets key: invalid base64: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("secrets key: must be 32 bytes, got %d", len(key))
+	}
+	return key, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("secrets key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// defaultSecretProviders menyusun provider bawaan dari environment:
+// <KEY>_FILE selalu aktif, file terenkripsi aktif jika SECRETS_FILE diset
+// (key dari SECRETS_KEY atau SECRETS_KEY_FILE).
+func defaultSecretProviders(ctx context.Context) ([]SecretProvider, error) {
+	providers := []SecretProvider{FileEnvProvider{}}
+
+	path := strings.TrimSpace(os.Getenv("SECRETS_FILE"))
+	if path == "" {
+		return providers, nil
+	}
+
+	encodedKey, ok, err := FileEnvProvider{}.Lookup(ctx, "SECRETS_KEY")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		encodedKey = os.Getenv("SECRETS_KEY")
+	}
+	if encodedKey == "" {
+		return nil, errors.New("SECRETS_FILE is set but SECRETS_KEY or SECRETS_KEY_FILE is missing")
+	}
+
+	key, err := DecodeSecretsKey(encodedKey)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := NewEncryptedFileProvider(path, key)
+	if err != nil {
+		return nil, err
+	}
+	return append(providers, encrypted), nil
+}
+
+// knownDefaultSecrets adalah nilai JWT secret yang pernah dipakai contoh atau
+// tutorial; tidak boleh dipakai di production walaupun cukup panjang.
+var knownDefaultSecrets = map[string]bool{
+	"secret":          true,
+	"changeme":        true,
+	"change-me":       true,
+	"jwt_secret":      true,
+	"jwt-secret":      true,
+	"your-secret-key": true,
+	"your_secret_key": true,
+	"mysecret":        true,
+	"password":        true,
+	"mz_ilcs_go":      true,
+}
+
+const minProductionJWTSecretLength = 32
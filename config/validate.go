@@ -0,0 +1,79 @@
+// config/validate.go
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Validate memeriksa seluruh Config dan mengembalikan semua pelanggaran
+// sekaligus, bukan hanya yang pertama, agar mudah diperbaiki dalam satu kali jalan.
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	switch c.App.Env {
+	case "development", "staging", "production":
+	default:
+		errs = append(errs, fmt.Errorf("app.env: must be development, staging or production, got %q", c.App.Env))
+	}
+
+	check(validPort(c.Server.Port), "server.port: must be between 1 and 65535, got %d", c.Server.Port)
+	check(c.Server.ReadTimeout > 0, "server.read_timeout: must be positive")
+	check(c.Server.WriteTimeout > 0, "server.write_timeout: must be positive")
+	check(c.Server.IdleTimeout > 0, "server.idle_timeout: must be positive")
+	check(c.Server.MaxHeaderBytes > 0, "server.max_header_bytes: must be positive")
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout: must be positive")
+
+	check(c.Database.Type == "postgres" || c.Database.Type == "oracle", "database.type: must be postgres or oracle, got %q", c.Database.Type)
+	check(c.Database.Host != "", "database.host: required")
+	check(validPort(c.Database.Port), "database.port: must be between 1 and 65535, got %d", c.Database.Port)
+	check(c.Database.User != "", "database.user: required")
+	check(c.Database.Name != "", "database.name: required")
+	check(c.Database.MaxOpenConns >= 0, "database.max_open_conns: must not be negative")
+	check(c.Database.MaxIdleConns >= 0, "database.max_idle_conns: must not be negative")
+	check(c.Database.MaxOpenConns == 0 || c.Database.MaxIdleConns <= c.Database.MaxOpenConns,
+		"database.max_idle_conns: must not exceed max_open_conns (%d)", c.Database.MaxOpenConns)
+	check(c.Database.ConnMaxLifetime >= 0, "database.conn_max_lifetime: must not be negative")
+
+	check(c.Redis.Addr != "", "redis.addr: required")
+	check(c.Redis.DB >= 0 && c.Redis.DB <= 15, "redis.db: must be between 0 and 15, got %d", c.Redis.DB)
+	check(c.Redis.PoolSize > 0, "redis.pool_size: must be positive")
+
+	check(c.JWT.Secret != "", "jwt.secret: required")
+	if c.App.Env == "production" && c.JWT.Secret != "" {
+		check(!knownDefaultSecrets[strings.ToLower(c.JWT.Secret)], "jwt.secret: must not be a known default value in production")
+		check(len(c.JWT.Secret) >= minProductionJWTSecretLength, "jwt.secret: must be at least %d characters in production", minProductionJWTSecretLength)
+	}
+	check(c.JWT.TokenTTL > 0, "jwt.token_ttl: must be positive")
+
+	switch c.Tracing.Exporter {
+	case "none", "stdout", "otlp":
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter: must be none, stdout or otlp, got %q", c.Tracing.Exporter))
+	}
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio: must be between 0 and 1")
+
+	if _, err := logrus.ParseLevel(c.Log.Level); err != nil {
+		errs = append(errs, fmt.Errorf("log.level: %w", err))
+	}
+	check(c.RateLimit.RequestsPerSecond >= 0, "rate_limit.requests_per_second: must not be negative")
+	check(c.RateLimit.RequestsPerSecond == 0 || c.RateLimit.Burst > 0, "rate_limit.burst: must be positive when rate limiting is enabled")
+	check(c.Cache.TaskTTL > 0, "cache.task_ttl: must be positive")
+	check(c.Idempotency.TTL > 0, "idempotency.ttl: must be positive")
+	check(c.Tasks.MaxSubtaskDepth >= 1, "tasks.max_subtask_depth: must be at least 1, got %d", c.Tasks.MaxSubtaskDepth)
+	check(c.App.ReloadInterval >= 0, "app.reload_interval: must not be negative")
+
+	return errors.Join(errs...)
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
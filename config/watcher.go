@@ -0,0 +1,150 @@
+// config/watcher.go
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Watcher menyimpan Config yang sedang berlaku dan memuat ulang field
+// bertanda `reload:"true"` saat SIGHUP atau saat file konfigurasi berubah.
+// Field lain tetap memakai nilai saat start; perubahannya hanya dicatat
+// sebagai peringatan karena butuh restart.
+type Watcher struct {
+	current     atomic.Pointer[Config]
+	load        func() (Config, error)
+	logger      *logrus.Logger
+	mu          sync.Mutex
+	subscribers []func(Config)
+}
+
+// NewWatcher membuat Watcher dengan konfigurasi awal. load dipanggil setiap
+// reload untuk membaca ulang semua lapisan; nil berarti konfigurasi statis.
+func NewWatcher(initial Config, load func() (Config, error), logger *logrus.Logger) *Watcher {
+	w := &Watcher{load: load, logger: logger}
+	w.current.Store(&initial)
+	return w
+}
+
+// Current mengembalikan snapshot konfigurasi yang sedang berlaku.
+func (w *Watcher) Current() Config {
+	return *w.current.Load()
+}
+
+// Subscribe mendaftarkan fn yang dipanggil dengan snapshot baru setiap kali
+// reload berhasil. fn langsung dipanggil sekali dengan konfigurasi saat ini.
+// Subscriber dipanggil berurutan sehingga tidak pernah melihat dua versi
+// konfigurasi secara bersamaan.
+func (w *Watcher) Subscribe(fn func(Config)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.subscribers = append(w.subscribers, fn)
+	fn(w.Current())
+}
+
+// Reload membaca ulang konfigurasi. Jika konfigurasi baru tidak valid,
+// konfigurasi lama tetap dipakai dan error dikembalikan.
+func (w *Watcher) Reload() error {
+	if w.load == nil {
+		return nil
+	}
+
+	next, err := w.load()
+	if err != nil {
+		w.logger.WithError(err).Error("Config reload rejected, keeping current configuration")
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	old := w.Current()
+	merged := old
+	changed := logrus.Fields{}
+	var needsRestart []string
+
+	oldFields, nextFields, mergedFields := old.fields(), next.fields(), merged.fields()
+	for i, f := range oldFields {
+		if reflect.DeepEqual(f.value.Interface(), nextFields[i].value.Interface()) {
+			continue
+		}
+		if !f.Reloadable {
+			needsRestart = append(needsRestart, f.Key)
+			continue
+		}
+		mergedFields[i].value.Set(nextFields[i].value)
+
+		from, to := f.String(), nextFields[i].String()
+		if f.Secret {
+			from, to = redacted, redacted
+		}
+		changed[f.Key] = from + " -> " + to
+	}
+
+	if len(needsRestart) > 0 {
+		w.logger.WithField("keys", needsRestart).Warn("Config changes ignored until restart")
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	w.current.Store(&merged)
+	for _, fn := range w.subscribers {
+		fn(merged)
+	}
+	w.logger.WithFields(changed).Info("Configuration reloaded")
+	return nil
+}
+
+// Run memuat ulang konfigurasi saat SIGHUP dan, jika interval > 0, saat
+// salah satu paths berubah (berdasarkan waktu modifikasi). Run berhenti
+// ketika ctx dibatalkan.
+func (w *Watcher) Run(ctx context.Context, interval time.Duration, paths ...string) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	modTimes := statPaths(paths)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			w.logger.Info("SIGHUP received, reloading configuration")
+			w.Reload()
+		case <-tick:
+			latest := statPaths(paths)
+			if !reflect.DeepEqual(latest, modTimes) {
+				modTimes = latest
+				w.logger.Info("Config file changed, reloading configuration")
+				w.Reload()
+			}
+		}
+	}
+}
+
+func statPaths(paths []string) map[string]time.Time {
+	modTimes := make(map[string]time.Time, len(paths))
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			modTimes[path] = info.ModTime()
+		}
+	}
+	return modTimes
+}
@@ -2,35 +2,51 @@
 package controllers
 
 import (
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
 	"github.com/programmercintasunnah/go-todolist-ilcs/utils"
 )
 
+type AuthController struct {
+	jwtSecret string
+	tokenTTL  time.Duration
+}
+
+func NewAuthController(jwtSecret string, tokenTTL time.Duration) *AuthController {
+	return &AuthController{
+		jwtSecret: jwtSecret,
+		tokenTTL:  tokenTTL,
+	}
+}
+
 type LoginInput struct {
 	Username string `json:"username" binding:"required"`
 	Password string `json:"password" binding:"required"`
+	// Locale adalah preferensi bahasa pesan API (en atau id), disimpan di token
+	Locale string `json:"locale" binding:"omitempty,oneof=en id"`
 }
 
-func Login(c *gin.Context) {
+func (ac *AuthController) Login(c *gin.Context) {
 	var input LoginInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(utils.BindingError(err))
 		return
 	}
 
 	// Dummy authentication. Gantilah dengan autentikasi yang sebenarnya.
 	if input.Username != "admin" || input.Password != "password" {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+		c.Error(apperrors.Unauthorized("invalid_credentials", "Invalid credentials"))
 		return
 	}
 
 	// Generate JWT Token
-	token, err := utils.GenerateJWT(input.Username, time.Hour*72)
+	token, err := utils.GenerateJWT(input.Username, input.Locale, ac.jwtSecret, ac.tokenTTL)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		c.Error(fmt.Errorf("generate token: %w", err))
 		return
 	}
 
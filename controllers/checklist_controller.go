@@ -0,0 +1,147 @@
+// controllers/checklist_controller.go
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/services"
+	"github.com/programmercintasunnah/go-todolist-ilcs/utils"
+	"github.com/sirupsen/logrus"
+)
+
+type ChecklistController struct {
+	service services.ChecklistService
+	logger  *logrus.Logger
+}
+
+func NewChecklistController(service services.ChecklistService, logger *logrus.Logger) *ChecklistController {
+	return &ChecklistController{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// ChecklistItemInput adalah body POST /api/tasks/:id/checklist dan
+// PUT /api/tasks/:id/checklist/:item_id.
+type ChecklistItemInput struct {
+	Text string `json:"text" binding:"required,max=200"`
+	Done bool   `json:"done"`
+}
+
+// AddItem menambahkan item di akhir checklist task. If-Match berlaku
+// seperti pada update task karena checklist termasuk representasi task.
+func (cc *ChecklistController) AddItem(c *gin.Context) {
+	id, err := taskID("id", c.Param("id"))
+	if err != nil {
+		cc.logger.Error("AddItem: Invalid ID", err)
+		c.Error(err)
+		return
+	}
+
+	var input ChecklistItemInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		cc.logger.Error("AddItem: Invalid input", err)
+		c.Error(utils.BindingError(err))
+		return
+	}
+
+	version, err := ifMatchVersion(c, id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	item := models.ChecklistItem{Text: input.Text, Done: input.Done}
+	task, err := cc.service.AddItem(c.Request.Context(), id, version, &item)
+	if err != nil {
+		cc.logger.Error("AddItem: Failed to add checklist item", err)
+		c.Error(err)
+		return
+	}
+
+	c.Header("ETag", task.ETag())
+	c.JSON(http.StatusCreated, gin.H{
+		"message": message(c, "checklist.added", "Checklist item added successfully"),
+		"item":    item,
+		"task":    task,
+	})
+}
+
+func (cc *ChecklistController) UpdateItem(c *gin.Context) {
+	id, err := taskID("id", c.Param("id"))
+	if err != nil {
+		cc.logger.Error("UpdateItem: Invalid ID", err)
+		c.Error(err)
+		return
+	}
+	itemID, err := checklistItemID("item_id", c.Param("item_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var input ChecklistItemInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		cc.logger.Error("UpdateItem: Invalid input", err)
+		c.Error(utils.BindingError(err))
+		return
+	}
+
+	version, err := ifMatchVersion(c, id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	task, err := cc.service.UpdateItem(c.Request.Context(), id, version, models.ChecklistItem{ID: itemID, Text: input.Text, Done: input.Done})
+	if err != nil {
+		cc.logger.Error("UpdateItem: Failed to update checklist item", err)
+		c.Error(err)
+		return
+	}
+
+	c.Header("ETag", task.ETag())
+	c.JSON(http.StatusOK, gin.H{
+		"message": message(c, "task.updated", "Task updated successfully"),
+		"task":    task,
+	})
+}
+
+func (cc *ChecklistController) DeleteItem(c *gin.Context) {
+	id, err := taskID("id", c.Param("id"))
+	if err != nil {
+		cc.logger.Error("DeleteItem: Invalid ID", err)
+		c.Error(err)
+		return
+	}
+	itemID, err := checklistItemID("item_id", c.Param("item_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	version, err := ifMatchVersion(c, id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	task, err := cc.service.DeleteItem(c.Request.Context(), id, version, itemID)
+	if err != nil {
+		cc.logger.Error("DeleteItem: Failed to delete checklist item", err)
+		c.Error(err)
+		return
+	}
+
+	c.Header("ETag", task.ETag())
+	c.JSON(http.StatusOK, gin.H{
+		"message": message(c, "task.updated", "Task updated successfully"),
+		"task":    task,
+	})
+}
+
+func checklistItemID(field, value string) (uint, error) {
+	return pathID(field, value, "invalid_checklist_item_id", "Checklist item ID must be a positive integer")
+}
@@ -0,0 +1,129 @@
+// controllers/dependency_controller.go
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/programmercintasunnah/go-todolist-ilcs/services"
+	"github.com/programmercintasunnah/go-todolist-ilcs/utils"
+	"github.com/sirupsen/logrus"
+)
+
+type DependencyController struct {
+	service services.DependencyService
+	logger  *logrus.Logger
+}
+
+func NewDependencyController(service services.DependencyService, logger *logrus.Logger) *DependencyController {
+	return &DependencyController{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// LinkTasksInput adalah body POST /api/tasks/:id/dependencies: task :id
+// diblokir oleh task BlockedBy.
+type LinkTasksInput struct {
+	BlockedBy uint `json:"blocked_by" binding:"required"`
+}
+
+type dependencyNode struct {
+	ID     uint   `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+}
+
+type dependencyGraphResponse struct {
+	TaskID    uint                `json:"task_id"`
+	BlockedBy []uint              `json:"blocked_by"`
+	Blocking  []uint              `json:"blocking"`
+	Nodes     []dependencyNode    `json:"nodes"`
+	Edges     []models.Dependency `json:"edges"`
+}
+
+func newDependencyGraphResponse(graph *repositories.DependencyGraph) dependencyGraphResponse {
+	nodes := make([]dependencyNode, len(graph.Nodes))
+	for i, node := range graph.Nodes {
+		nodes[i] = dependencyNode{ID: node.ID, Title: node.Title, Status: node.Status}
+	}
+	return dependencyGraphResponse{
+		TaskID:    graph.TaskID,
+		BlockedBy: graph.BlockedBy,
+		Blocking:  graph.Blocking,
+		Nodes:     nodes,
+		Edges:     graph.Edges,
+	}
+}
+
+// GetGraph mengembalikan graf dependensi task: pemblokir dan task yang
+// diblokir, langsung maupun tidak langsung.
+func (dc *DependencyController) GetGraph(c *gin.Context) {
+	id, err := taskID("id", c.Param("id"))
+	if err != nil {
+		dc.logger.Error("GetGraph: Invalid ID", err)
+		c.Error(err)
+		return
+	}
+
+	graph, err := dc.service.GetGraph(c.Request.Context(), id)
+	if err != nil {
+		dc.logger.Error("GetGraph: Failed to retrieve dependencies", err)
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, newDependencyGraphResponse(graph))
+}
+
+func (dc *DependencyController) LinkTasks(c *gin.Context) {
+	id, err := taskID("id", c.Param("id"))
+	if err != nil {
+		dc.logger.Error("LinkTasks: Invalid ID", err)
+		c.Error(err)
+		return
+	}
+
+	var input LinkTasksInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		dc.logger.Error("LinkTasks: Invalid input", err)
+		c.Error(utils.BindingError(err))
+		return
+	}
+
+	if err := dc.service.LinkTasks(c.Request.Context(), id, input.BlockedBy); err != nil {
+		dc.logger.Error("LinkTasks: Failed to link tasks", err)
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":    message(c, "dependency.created", "Dependency created successfully"),
+		"dependency": models.Dependency{TaskID: id, BlockerID: input.BlockedBy},
+	})
+}
+
+func (dc *DependencyController) UnlinkTasks(c *gin.Context) {
+	id, err := taskID("id", c.Param("id"))
+	if err != nil {
+		dc.logger.Error("UnlinkTasks: Invalid ID", err)
+		c.Error(err)
+		return
+	}
+	blocker, err := taskID("blocker_id", c.Param("blocker_id"))
+	if err != nil {
+		dc.logger.Error("UnlinkTasks: Invalid blocker ID", err)
+		c.Error(err)
+		return
+	}
+
+	if err := dc.service.UnlinkTasks(c.Request.Context(), id, blocker); err != nil {
+		dc.logger.Error("UnlinkTasks: Failed to unlink tasks", err)
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message(c, "dependency.deleted", "Dependency deleted successfully"),
+	})
+}
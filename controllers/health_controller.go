@@ -0,0 +1,113 @@
+// controllers/health_controller.go
+package controllers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/programmercintasunnah/go-todolist-ilcs/migrations"
+	"github.com/sirupsen/logrus"
+)
+
+const readinessCheckTimeout = 2 * time.Second
+
+type HealthController struct {
+	db          *sql.DB
+	redisClient *redis.Client
+	logger      *logrus.Logger
+}
+
+func NewHealthController(db *sql.DB, redisClient *redis.Client, logger *logrus.Logger) *HealthController {
+	return &HealthController{
+		db:          db,
+		redisClient: redisClient,
+		logger:      logger,
+	}
+}
+
+type dependencyStatus struct {
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Liveness hanya menandakan proses masih hidup; tidak menyentuh dependency
+// agar orchestrator tidak me-restart instance saat DB sedang bermasalah.
+func (hc *HealthController) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readiness memeriksa DB, Redis dan versi migrasi. Jika salah satu gagal,
+// respons 503 sehingga instance dikeluarkan dari load balancer.
+func (hc *HealthController) Readiness(c *gin.Context) {
+	checks := map[string]func(ctx context.Context) error{
+		"database":  func(ctx context.Context) error { return hc.db.PingContext(ctx) },
+		"redis":     func(ctx context.Context) error { return hc.redisClient.Ping(ctx).Err() },
+		"migration": hc.checkMigration,
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[string]dependencyStatus, len(checks))
+	ready := true
+
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check func(ctx context.Context) error) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(c.Request.Context(), readinessCheckTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := check(ctx)
+			result := dependencyStatus{
+				Status:    "up",
+				LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+			}
+			if err != nil {
+				result.Status = "down"
+				result.Error = err.Error()
+				hc.logger.WithField("dependency", name).Warn("Readiness: dependency check failed: ", err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			results[name] = result
+			if err != nil {
+				ready = false
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	status, code := "ok", http.StatusOK
+	if !ready {
+		status, code = "unavailable", http.StatusServiceUnavailable
+	}
+
+	c.JSON(code, gin.H{
+		"status": status,
+		"checks": results,
+	})
+}
+
+// checkMigration gagal jika skema tertinggal dari migrasi yang dikenal binary
+// ini. Skema yang lebih baru diterima agar instance lama tetap siap selama
+// rolling deploy setelah instance baru menjalankan migrasinya.
+func (hc *HealthController) checkMigration(ctx context.Context) error {
+	current, err := migrations.Version(ctx, hc.db)
+	if err != nil {
+		return err
+	}
+	if latest := migrations.Latest(); current < latest {
+		return fmt.Errorf("schema version %d, expected at least %d", current, latest)
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+// controllers/project_controller.go
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/programmercintasunnah/go-todolist-ilcs/services"
+	"github.com/programmercintasunnah/go-todolist-ilcs/utils"
+	"github.com/sirupsen/logrus"
+)
+
+type ProjectController struct {
+	service services.ProjectService
+	logger  *logrus.Logger
+}
+
+func NewProjectController(service services.ProjectService, logger *logrus.Logger) *ProjectController {
+	return &ProjectController{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// ProjectInput adalah body POST /api/projects dan PUT /api/projects/:id.
+// Color berupa warna hex, mis. "#1e90ff".
+type ProjectInput struct {
+	Name  string `json:"name" binding:"required,max=100"`
+	Color string `json:"color" binding:"omitempty,hexcolor"`
+}
+
+// ListProjectsQuery adalah query GET /api/projects. Archived "false"
+// (bawaan) menyembunyikan project yang diarsipkan, "all" menampilkan semua.
+type ListProjectsQuery struct {
+	Archived string `form:"archived" binding:"omitempty,oneof=true false all"`
+}
+
+// MoveTaskInput adalah body PUT /api/tasks/:id/project; project_id null
+// mengeluarkan task dari project-nya.
+type MoveTaskInput struct {
+	ProjectID *uint `json:"project_id" binding:"omitempty,min=1"`
+}
+
+type projectResponse struct {
+	models.Project
+	PendingTasks   int `json:"pending_tasks"`
+	CompletedTasks int `json:"completed_tasks"`
+}
+
+func newProjectResponse(project repositories.ProjectSummary) projectResponse {
+	return projectResponse{Project: project.Project, PendingTasks: project.Pending, CompletedTasks: project.Completed}
+}
+
+// projectContextKey menyimpan project dari RequireProject di gin.Context.
+const projectContextKey = "project"
+
+// ListProjects mengembalikan project beserta jumlah task pending dan
+// completed di masing-masing project.
+func (pc *ProjectController) ListProjects(c *gin.Context) {
+	var query ListProjectsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		pc.logger.Error("ListProjects: Invalid query parameters", err)
+		c.Error(utils.BindingError(err))
+		return
+	}
+
+	var archived *bool
+	if query.Archived != "all" {
+		value := query.Archived == "true"
+		archived = &value
+	}
+	projects, err := pc.service.ListProjects(c.Request.Context(), archived)
+	if err != nil {
+		pc.logger.Error("ListProjects: Failed to retrieve projects", err)
+		c.Error(err)
+		return
+	}
+
+	items := make([]projectResponse, len(projects))
+	for i, project := range projects {
+		items[i] = newProjectResponse(project)
+	}
+	c.JSON(http.StatusOK, gin.H{"projects": items})
+}
+
+func (pc *ProjectController) GetProject(c *gin.Context) {
+	id, err := projectID("id", c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	project, err := pc.service.GetProject(c.Request.Context(), id)
+	if err != nil {
+		pc.logger.Error("GetProject: Failed to retrieve project", err)
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, newProjectResponse(*project))
+}
+
+func (pc *ProjectController) CreateProject(c *gin.Context) {
+	var input ProjectInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		pc.logger.Error("CreateProject: Invalid input", err)
+		c.Error(utils.BindingError(err))
+		return
+	}
+
+	project := models.Project{Name: input.Name, Color: input.Color}
+	if err := pc.service.CreateProject(c.Request.Context(), c.GetString("username"), &project); err != nil {
+		pc.logger.Error("CreateProject: Failed to create project", err)
+		c.Error(err)
+		return
+	}
+
+	c.Header("Location", strings.TrimSuffix(c.Request.URL.Path, "/")+"/"+strconv.FormatUint(uint64(project.ID), 10))
+	c.JSON(http.StatusCreated, gin.H{
+		"message": message(c, "project.created", "Project created successfully"),
+		"project": projectResponse{Project: project},
+	})
+}
+
+// UpdateProject mengganti nama dan warna project; hanya owner yang boleh.
+func (pc *ProjectController) UpdateProject(c *gin.Context) {
+	id, err := projectID("id", c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var input ProjectInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		pc.logger.Error("UpdateProject: Invalid input", err)
+		c.Error(utils.BindingError(err))
+		return
+	}
+
+	project, err := pc.service.UpdateProject(c.Request.Context(), c.GetString("username"), &models.Project{ID: id, Name: input.Name, Color: input.Color})
+	if err != nil {
+		pc.logger.Error("UpdateProject: Failed to update project", err)
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": message(c, "project.updated", "Project updated successfully"),
+		"project": newProjectResponse(*project),
+	})
+}
+
+// DeleteProject hanya menghapus project kosong; task harus dipindahkan atau
+// dihapus terlebih dahulu.
+func (pc *ProjectController) DeleteProject(c *gin.Context) {
+	id, err := projectID("id", c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := pc.service.DeleteProject(c.Request.Context(), c.GetString("username"), id); err != nil {
+		pc.logger.Error("DeleteProject: Failed to delete project", err)
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message(c, "project.deleted", "Project deleted successfully"),
+	})
+}
+
+// ArchiveProject mengarsipkan project; task-nya tidak lagi muncul di
+// GET /api/tasks kecuali dengan archived=true atau archived=all.
+func (pc *ProjectController) ArchiveProject(c *gin.Context) {
+	pc.setArchived(c, true)
+}
+
+func (pc *ProjectController) UnarchiveProject(c *gin.Context) {
+	pc.setArchived(c, false)
+}
+
+func (pc *ProjectController) setArchived(c *gin.Context, archived bool) {
+	id, err := projectID("id", c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := pc.service.ArchiveProject(c.Request.Context(), c.GetString("username"), id, archived); err != nil {
+		pc.logger.Error("ArchiveProject: Failed to change archived flag", err)
+		c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// MoveTask memindahkan task ke project lain. If-Match berlaku seperti pada
+// update task karena project termasuk representasi task.
+func (pc *ProjectController) MoveTask(c *gin.Context) {
+	id, err := taskID("id", c.Param("id"))
+	if err != nil {
+		pc.logger.Error("MoveTask: Invalid ID", err)
+		c.Error(err)
+		return
+	}
+
+	var input MoveTaskInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		pc.logger.Error("MoveTask: Invalid input", err)
+		c.Error(utils.BindingError(err))
+		return
+	}
+
+	version, err := ifMatchVersion(c, id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	task, err := pc.service.MoveTask(c.Request.Context(), id, version, input.ProjectID)
+	if err != nil {
+		pc.logger.Error("MoveTask: Failed to move task", err)
+		c.Error(err)
+		return
+	}
+
+	c.Header("ETag", task.ETag())
+	c.JSON(http.StatusOK, gin.H{
+		"message": message(c, "task.updated", "Task updated successfully"),
+		"task":    task,
+	})
+}
+
+// RequireProject memuat project :id untuk route bersarang
+// /api/projects/:id/tasks, sehingga handler task bekerja di dalam project
+// tersebut (lihat scopedProject).
+func (pc *ProjectController) RequireProject(c *gin.Context) {
+	id, err := projectID("id", c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		c.Abort()
+		return
+	}
+
+	project, err := pc.service.GetProject(c.Request.Context(), id)
+	if err != nil {
+		pc.logger.Error("RequireProject: Failed to retrieve project", err)
+		c.Error(err)
+		c.Abort()
+		return
+	}
+	c.Set(projectContextKey, project.Project)
+	c.Next()
+}
+
+// scopedProject mengembalikan project dari RequireProject, atau nil di luar
+// route bersarang.
+func scopedProject(c *gin.Context) *models.Project {
+	if value, ok := c.Get(projectContextKey); ok {
+		project := value.(models.Project)
+		return &project
+	}
+	return nil
+}
+
+// projectID mem-parse ID project dari path.
+func projectID(field, value string) (uint, error) {
+	return pathID(field, value, "invalid_project_id", "Project ID must be a positive integer")
+}
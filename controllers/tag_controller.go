@@ -0,0 +1,262 @@
+// controllers/tag_controller.go
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/programmercintasunnah/go-todolist-ilcs/services"
+	"github.com/programmercintasunnah/go-todolist-ilcs/utils"
+	"github.com/sirupsen/logrus"
+)
+
+type TagController struct {
+	service services.TagService
+	logger  *logrus.Logger
+}
+
+func NewTagController(service services.TagService, logger *logrus.Logger) *TagController {
+	return &TagController{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// TagInput adalah body POST /api/tags dan PUT /api/tags/:id (rename).
+type TagInput struct {
+	Name string `json:"name" binding:"required,max=50"`
+}
+
+// MergeTagsInput adalah body POST /api/tags/:id/merge; tag :id digabung ke
+// tag Into lalu dihapus.
+type MergeTagsInput struct {
+	Into uint `json:"into" binding:"required"`
+}
+
+// AttachTagsInput adalah body POST /api/tasks/:id/tags. Tag yang belum ada
+// dibuat otomatis.
+type AttachTagsInput struct {
+	Tags []string `json:"tags" binding:"required,min=1,max=20,dive,required,max=50"`
+}
+
+type tagResponse struct {
+	models.Tag
+	TaskCount int `json:"task_count"`
+}
+
+func newTagResponse(tag repositories.TagUsage) tagResponse {
+	return tagResponse{Tag: tag.Tag, TaskCount: tag.TaskCount}
+}
+
+// ListTags mengembalikan semua tag beserta jumlah task yang memakainya.
+func (tc *TagController) ListTags(c *gin.Context) {
+	tags, err := tc.service.ListTags(c.Request.Context())
+	if err != nil {
+		tc.logger.Error("ListTags: Failed to retrieve tags", err)
+		c.Error(err)
+		return
+	}
+
+	items := make([]tagResponse, len(tags))
+	for i, tag := range tags {
+		items[i] = newTagResponse(tag)
+	}
+	c.JSON(http.StatusOK, gin.H{"tags": items})
+}
+
+func (tc *TagController) GetTag(c *gin.Context) {
+	id, err := tagID("id", c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	tag, err := tc.service.GetTag(c.Request.Context(), id)
+	if err != nil {
+		tc.logger.Error("GetTag: Failed to retrieve tag", err)
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, newTagResponse(*tag))
+}
+
+func (tc *TagController) CreateTag(c *gin.Context) {
+	var input TagInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		tc.logger.Error("CreateTag: Invalid input", err)
+		c.Error(utils.BindingError(err))
+		return
+	}
+
+	tag := models.Tag{Name: input.Name}
+	if err := tc.service.CreateTag(c.Request.Context(), &tag); err != nil {
+		tc.logger.Error("CreateTag: Failed to create tag", err)
+		c.Error(err)
+		return
+	}
+
+	c.Header("Location", strings.TrimSuffix(c.Request.URL.Path, "/")+"/"+strconv.FormatUint(uint64(tag.ID), 10))
+	c.JSON(http.StatusCreated, gin.H{
+		"message": message(c, "tag.created", "Tag created successfully"),
+		"tag":     tagResponse{Tag: tag},
+	})
+}
+
+// RenameTag mengganti nama tag; semua task yang memakainya ikut berubah.
+func (tc *TagController) RenameTag(c *gin.Context) {
+	id, err := tagID("id", c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var input TagInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		tc.logger.Error("RenameTag: Invalid input", err)
+		c.Error(utils.BindingError(err))
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := tc.service.RenameTag(ctx, &models.Tag{ID: id, Name: input.Name}); err != nil {
+		tc.logger.Error("RenameTag: Failed to rename tag", err)
+		c.Error(err)
+		return
+	}
+	tag, err := tc.service.GetTag(ctx, id)
+	if err != nil {
+		tc.logger.Error("RenameTag: Failed to reload tag", err)
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": message(c, "tag.renamed", "Tag renamed successfully"),
+		"tag":     newTagResponse(*tag),
+	})
+}
+
+// MergeTags memindahkan semua task dari tag :id ke tag into lalu menghapus
+// tag :id.
+func (tc *TagController) MergeTags(c *gin.Context) {
+	id, err := tagID("id", c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var input MergeTagsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		tc.logger.Error("MergeTags: Invalid input", err)
+		c.Error(utils.BindingError(err))
+		return
+	}
+
+	tag, err := tc.service.MergeTags(c.Request.Context(), id, input.Into)
+	if err != nil {
+		tc.logger.Error("MergeTags: Failed to merge tags", err)
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": message(c, "tag.merged", "Tags merged successfully"),
+		"tag":     newTagResponse(*tag),
+	})
+}
+
+func (tc *TagController) DeleteTag(c *gin.Context) {
+	id, err := tagID("id", c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := tc.service.DeleteTag(c.Request.Context(), id); err != nil {
+		tc.logger.Error("DeleteTag: Failed to delete tag", err)
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message(c, "tag.deleted", "Tag deleted successfully"),
+	})
+}
+
+// AttachTags memasang tag ke task. If-Match berlaku seperti pada update
+// task karena tag termasuk representasi task.
+func (tc *TagController) AttachTags(c *gin.Context) {
+	id, err := taskID("id", c.Param("id"))
+	if err != nil {
+		tc.logger.Error("AttachTags: Invalid ID", err)
+		c.Error(err)
+		return
+	}
+
+	var input AttachTagsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		tc.logger.Error("AttachTags: Invalid input", err)
+		c.Error(utils.BindingError(err))
+		return
+	}
+
+	version, err := ifMatchVersion(c, id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	task, err := tc.service.AttachTags(c.Request.Context(), id, version, input.Tags)
+	if err != nil {
+		tc.logger.Error("AttachTags: Failed to attach tags", err)
+		c.Error(err)
+		return
+	}
+
+	c.Header("ETag", task.ETag())
+	c.JSON(http.StatusOK, gin.H{
+		"message": message(c, "task.updated", "Task updated successfully"),
+		"task":    task,
+	})
+}
+
+func (tc *TagController) DetachTag(c *gin.Context) {
+	id, err := taskID("id", c.Param("id"))
+	if err != nil {
+		tc.logger.Error("DetachTag: Invalid ID", err)
+		c.Error(err)
+		return
+	}
+	tag, err := tagID("tag_id", c.Param("tag_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	version, err := ifMatchVersion(c, id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	task, err := tc.service.DetachTag(c.Request.Context(), id, version, tag)
+	if err != nil {
+		tc.logger.Error("DetachTag: Failed to detach tag", err)
+		c.Error(err)
+		return
+	}
+
+	c.Header("ETag", task.ETag())
+	c.JSON(http.StatusOK, gin.H{
+		"message": message(c, "task.updated", "Task updated successfully"),
+		"task":    task,
+	})
+}
+
+// tagID mem-parse ID tag dari path.
+func tagID(field, value string) (uint, error) {
+	return pathID(field, value, "invalid_tag_id", "Tag ID must be a positive integer")
+}
@@ -0,0 +1,301 @@
+// controllers/task_bulk_controller.go
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/i18n"
+	"github.com/programmercintasunnah/go-todolist-ilcs/middlewares"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/programmercintasunnah/go-todolist-ilcs/utils"
+)
+
+const (
+	bulkModeAtomic  = "atomic"
+	bulkModePartial = "partial"
+)
+
+// BulkTaskInput adalah body POST /api/tasks/bulk. Mode atomic (bawaan)
+// membatalkan semua operasi jika satu gagal; mode partial menyimpan operasi
+// yang berhasil dan melaporkan yang gagal per item.
+type BulkTaskInput struct {
+	Mode       string               `json:"mode" binding:"omitempty,oneof=atomic partial"`
+	Operations []BulkOperationInput `json:"operations" binding:"required,min=1,max=100,dive"`
+}
+
+// BulkOperationInput adalah satu operasi. Field task (title, status, ...)
+// mengikuti aturan PUT dan wajib untuk create dan update; id wajib untuk
+// update dan delete, version opsional seperti If-Match.
+type BulkOperationInput struct {
+	Op           string `json:"op" binding:"required,oneof=create update delete"`
+	ID           uint   `json:"id"`
+	Version      uint   `json:"version"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	Status       string `json:"status"`
+	DueDate      string `json:"due_date"`
+	Priority     int    `json:"priority"`
+	AutoComplete bool   `json:"auto_complete"`
+}
+
+// operation mengubah input menjadi BulkOperation setelah divalidasi.
+func (input BulkOperationInput) operation() (repositories.BulkOperation, error) {
+	op := repositories.BulkOperation{
+		Op:   repositories.BulkOp(input.Op),
+		Task: models.Task{ID: input.ID, Version: input.Version},
+	}
+
+	if op.Op != repositories.BulkCreate && input.ID == 0 {
+		return op, apperrors.Validation("validation_failed", "Request validation failed", apperrors.FieldError{
+			Field:   "id",
+			Rule:    "required",
+			Message: utils.FieldMessage("required", ""),
+		})
+	}
+	if op.Op == repositories.BulkDelete {
+		return op, nil
+	}
+
+	fields := UpdateTaskInput{
+		Title:        input.Title,
+		Description:  input.Description,
+		Status:       input.Status,
+		DueDate:      input.DueDate,
+		Priority:     input.Priority,
+		AutoComplete: input.AutoComplete,
+	}
+	if err := binding.Validator.ValidateStruct(&fields); err != nil {
+		return op, utils.BindingError(err)
+	}
+	return op, fields.apply(&op.Task)
+}
+
+// BulkItemResult adalah hasil satu operasi pada respons bulk.
+type BulkItemResult struct {
+	Index  int                  `json:"index"`
+	Op     string               `json:"op"`
+	ID     uint                 `json:"id,omitempty"`
+	Status int                  `json:"status"`
+	Task   *taskResponse        `json:"task,omitempty"`
+	Error  *middlewares.Problem `json:"error,omitempty"`
+}
+
+// BulkTasks menjalankan banyak create, update dan delete dalam satu
+// transaksi. Pada mode atomic, kegagalan dilaporkan sebagai problem dengan
+// instance yang menunjuk ke operasinya (mis. /api/tasks/bulk#/operations/3).
+// Pada mode partial respons berstatus 207 jika ada operasi yang gagal.
+func (tc *TaskController) BulkTasks(c *gin.Context) {
+	var input BulkTaskInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		tc.logger.Error("BulkTasks: Invalid input", err)
+		c.Error(utils.BindingError(err))
+		return
+	}
+	atomic := input.Mode != bulkModePartial
+
+	results := make([]BulkItemResult, len(input.Operations))
+	ops := make([]repositories.BulkOperation, 0, len(input.Operations))
+	indexes := make([]int, 0, len(input.Operations))
+	for i, item := range input.Operations {
+		op, err := item.operation()
+		if err != nil {
+			if atomic {
+				tc.bulkFailure(c, i, err)
+				return
+			}
+			results[i] = tc.bulkResult(c, i, item, repositories.BulkResult{Err: err})
+			continue
+		}
+		ops = append(ops, op)
+		indexes = append(indexes, i)
+	}
+
+	written, err := tc.service.BulkTasks(c.Request.Context(), ops, atomic)
+	if err != nil {
+		var bulkErr *repositories.BulkError
+		if errors.As(err, &bulkErr) {
+			tc.bulkFailure(c, indexes[bulkErr.Index], bulkErr.Err)
+			return
+		}
+		tc.logger.Error("BulkTasks: Failed to run bulk operations", err)
+		c.Error(err)
+		return
+	}
+
+	failed := len(input.Operations) - len(ops)
+	for j, result := range written {
+		i := indexes[j]
+		results[i] = tc.bulkResult(c, i, input.Operations[i], result)
+		if result.Err != nil {
+			failed++
+		}
+	}
+
+	status := http.StatusOK
+	if failed > 0 {
+		status = http.StatusMultiStatus
+	}
+	mode := bulkModeAtomic
+	if !atomic {
+		mode = bulkModePartial
+	}
+	c.JSON(status, gin.H{
+		"mode":      mode,
+		"succeeded": len(results) - failed,
+		"failed":    failed,
+		"results":   results,
+	})
+}
+
+func (tc *TaskController) bulkResult(c *gin.Context, index int, item BulkOperationInput, result repositories.BulkResult) BulkItemResult {
+	out := BulkItemResult{Index: index, Op: item.Op, ID: item.ID}
+	if result.Err != nil {
+		problem := middlewares.NewProblem(result.Err, c.GetString(i18n.ContextKey))
+		if problem.Status >= http.StatusInternalServerError {
+			tc.logger.Error("BulkTasks: Operation failed", result.Err)
+		}
+		out.Status = problem.Status
+		out.Error = &problem
+		return out
+	}
+
+	out.Status = http.StatusOK
+	if item.Op == string(repositories.BulkCreate) {
+		out.Status = http.StatusCreated
+	}
+	if result.Task != nil {
+		out.ID = result.Task.ID
+		out.Task = &taskResponse{Task: *result.Task, ETag: result.Task.ETag()}
+	}
+	return out
+}
+
+// bulkFailure menulis problem untuk operasi yang menggagalkan mode atomic.
+// err tetap dicatat lewat c.Error agar middleware (mis. Idempotency)
+// memperlakukannya sebagai respons error; ErrorHandler tidak menulis ulang
+// karena respons sudah ditulis.
+func (tc *TaskController) bulkFailure(c *gin.Context, index int, err error) {
+	problem := middlewares.NewProblem(err, c.GetString(i18n.ContextKey))
+	problem.Instance = c.Request.URL.Path + "#/operations/" + strconv.Itoa(index)
+	if problem.Status >= http.StatusInternalServerError {
+		tc.logger.Error("BulkTasks: Operation failed", err)
+	}
+	c.Error(err)
+	middlewares.WriteProblem(c, problem)
+}
+
+// BulkUpdateByFilterInput adalah body POST /api/tasks/bulk/update: ubah
+// field di Set untuk semua task yang cocok dengan Filter.
+type BulkUpdateByFilterInput struct {
+	Filter TaskFilterQuery  `json:"filter"`
+	Set    BulkChangesInput `json:"set"`
+	DryRun bool             `json:"dry_run"`
+}
+
+type BulkChangesInput struct {
+	Status  string `json:"status" binding:"omitempty,oneof=pending completed"`
+	DueDate string `json:"due_date" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// BulkDeleteByFilterInput adalah body POST /api/tasks/bulk/delete.
+type BulkDeleteByFilterInput struct {
+	Filter TaskFilterQuery `json:"filter"`
+	DryRun bool            `json:"dry_run"`
+}
+
+// UpdateTasksByFilter mengubah semua task yang cocok dengan filter. Dengan
+// dry_run respons berisi jumlah dan ID yang akan terkena tanpa mengubahnya.
+func (tc *TaskController) UpdateTasksByFilter(c *gin.Context) {
+	var input BulkUpdateByFilterInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		tc.logger.Error("UpdateTasksByFilter: Invalid input", err)
+		c.Error(utils.BindingError(err))
+		return
+	}
+
+	where, err := input.Filter.expr()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if where == nil {
+		c.Error(emptyFilter())
+		return
+	}
+
+	var changes repositories.TaskChanges
+	if input.Set.Status != "" {
+		changes.Status = &input.Set.Status
+	}
+	if input.Set.DueDate != "" {
+		dueDate, err := time.Parse("2006-01-02", input.Set.DueDate)
+		if err != nil {
+			c.Error(invalidDueDate(err))
+			return
+		}
+		changes.DueDate = &dueDate
+	}
+	if changes == (repositories.TaskChanges{}) {
+		c.Error(apperrors.Validation("empty_changes", "Set at least one field to change"))
+		return
+	}
+
+	ids, err := tc.service.UpdateTasksByFilter(c.Request.Context(), where, changes, input.DryRun)
+	if err != nil {
+		tc.logger.Error("UpdateTasksByFilter: Failed to update tasks", err)
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"dry_run":  input.DryRun,
+		"affected": len(ids),
+		"ids":      ids,
+	})
+}
+
+// DeleteTasksByFilter menghapus semua task yang cocok dengan filter; lihat
+// UpdateTasksByFilter untuk dry_run.
+func (tc *TaskController) DeleteTasksByFilter(c *gin.Context) {
+	var input BulkDeleteByFilterInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		tc.logger.Error("DeleteTasksByFilter: Invalid input", err)
+		c.Error(utils.BindingError(err))
+		return
+	}
+
+	where, err := input.Filter.expr()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if where == nil {
+		c.Error(emptyFilter())
+		return
+	}
+	ids, err := tc.service.DeleteTasksByFilter(c.Request.Context(), where, input.DryRun)
+	if err != nil {
+		tc.logger.Error("DeleteTasksByFilter: Failed to delete tasks", err)
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"dry_run":  input.DryRun,
+		"affected": len(ids),
+		"ids":      ids,
+	})
+}
+
+// emptyFilter menolak operasi tanpa kriteria agar satu request tidak
+// mengubah atau menghapus seluruh task secara tidak sengaja.
+func emptyFilter() error {
+	return apperrors.Validation("empty_filter", "At least one filter criterion is required")
+}
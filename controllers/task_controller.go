@@ -2,26 +2,45 @@
 package controllers
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
+	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/filter"
+	"github.com/programmercintasunnah/go-todolist-ilcs/i18n"
 	"github.com/programmercintasunnah/go-todolist-ilcs/models"
 	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
 	"github.com/programmercintasunnah/go-todolist-ilcs/services"
+	"github.com/programmercintasunnah/go-todolist-ilcs/utils"
 	"github.com/sirupsen/logrus"
 )
 
 type TaskController struct {
-	service services.TaskService
-	logger  *logrus.Logger
+	service      services.TaskService
+	views        services.ViewService
+	cursorSecret string
+	logger       *logrus.Logger
 }
 
-func NewTaskController(service services.TaskService, logger *logrus.Logger) *TaskController {
+// NewTaskController membuat controller task; views menyediakan saved view
+// untuk GET /api/tasks dan cursorSecret menandatangani token cursor
+// pagination.
+func NewTaskController(service services.TaskService, views services.ViewService, cursorSecret string, logger *logrus.Logger) *TaskController {
 	return &TaskController{
-		service: service,
-		logger:  logger,
+		service:      service,
+		views:        views,
+		cursorSecret: cursorSecret,
+		logger:       logger,
 	}
 }
 
@@ -30,57 +49,319 @@ type CreateTaskInput struct {
 	Description string `json:"description"`
 	Status      string `json:"status" binding:"required,oneof=pending completed"`
 	DueDate     string `json:"due_date" binding:"required,datetime=2006-01-02"`
+	Priority    int    `json:"priority" binding:"min=0,max=3"`
+	// ProjectID diabaikan di POST /api/projects/:id/tasks; project dari
+	// path yang dipakai.
+	ProjectID *uint `json:"project_id" binding:"omitempty,min=1"`
+	// ParentID menjadikan task subtask; AutoComplete menyelesaikan task
+	// otomatis begitu semua subtask-nya completed.
+	ParentID     *uint `json:"parent_id" binding:"omitempty,min=1"`
+	AutoComplete bool  `json:"auto_complete"`
 }
 
 func (tc *TaskController) CreateTask(c *gin.Context) {
 	var input CreateTaskInput
 	if err := c.ShouldBindJSON(&input); err != nil {
 		tc.logger.Error("CreateTask: Invalid input", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(utils.BindingError(err))
 		return
 	}
 
 	dueDate, err := time.Parse("2006-01-02", input.DueDate)
 	if err != nil {
 		tc.logger.Error("CreateTask: Invalid due_date format", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid due_date format"})
+		c.Error(invalidDueDate(err))
 		return
 	}
 
 	task := models.Task{
-		Title:       input.Title,
-		Description: input.Description,
-		Status:      input.Status,
-		DueDate:     dueDate,
+		Title:        input.Title,
+		Description:  input.Description,
+		Status:       input.Status,
+		DueDate:      dueDate,
+		Priority:     input.Priority,
+		ProjectID:    input.ProjectID,
+		ParentID:     input.ParentID,
+		AutoComplete: input.AutoComplete,
+	}
+	location := strings.TrimSuffix(c.Request.URL.Path, "/")
+	if project := scopedProject(c); project != nil {
+		task.ProjectID = &project.ID
+		location = strings.TrimSuffix(location, "/projects/"+c.Param("id")+"/tasks") + "/tasks"
 	}
 
-	if err := tc.service.CreateTask(&task); err != nil {
+	if err := tc.service.CreateTask(c.Request.Context(), &task); err != nil {
 		tc.logger.Error("CreateTask: Failed to create task", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create task"})
+		c.Error(err)
 		return
 	}
 
+	c.Header("Location", location+"/"+strconv.FormatUint(uint64(task.ID), 10))
+	c.Header("ETag", task.ETag())
+
 	c.JSON(http.StatusCreated, gin.H{
-		"message": "Task created successfully",
+		"message": message(c, "task.created", "Task created successfully"),
 		"task":    task,
 	})
 }
 
+// TaskFilterQuery adalah kosakata filter yang sama untuk GET /api/tasks
+// (query string) dan operasi bulk berdasarkan filter (body JSON). Q berisi
+// ekspresi bahasa filter (lihat package filter), mis.
+// "status:pending due<2026-11-01 -title:draft"; field lain adalah bentuk
+// lama yang digabung dengan Q memakai AND. Search mencari substring di
+// title dan description tanpa membedakan huruf besar/kecil. FullText adalah
+// pencarian full-text dengan stemming dan peringkat relevansi, mis.
+// `report "quarterly review" -draft`.
+// Tags berisi nama tag dipisah koma; TagMatch "any" (bawaan) memilih task
+// yang memiliki salah satunya, "all" yang memiliki semuanya.
+type TaskFilterQuery struct {
+	Q             string `form:"q" json:"q"`
+	Status        string `form:"status" json:"status"`
+	Search        string `form:"search" json:"search"`
+	FullText      string `form:"full_text" json:"full_text"`
+	DueBefore     string `form:"due_before" json:"due_before" binding:"omitempty,datetime=2006-01-02"`
+	CreatedBefore string `form:"created_before" json:"created_before" binding:"omitempty,datetime=2006-01-02"`
+	Tags          string `form:"tags" json:"tags" binding:"max=1000"`
+	TagMatch      string `form:"tag_match" json:"tag_match" binding:"omitempty,oneof=any all"`
+}
+
+// expr mengubah query menjadi satu ekspresi filter; nil jika kosong.
+func (q TaskFilterQuery) expr() (filter.Expr, error) {
+	parsed, err := filter.Parse(q.Q)
+	if err != nil {
+		return nil, invalidFilter("q", err)
+	}
+	exprs := []filter.Expr{parsed}
+
+	legacy := []struct {
+		param, field string
+		op           filter.Op
+		value        string
+	}{
+		{"status", "status", filter.OpEq, q.Status},
+		{"due_before", "due_date", filter.OpLt, q.DueBefore},
+		{"created_before", "created_at", filter.OpLt, q.CreatedBefore},
+	}
+	for _, l := range legacy {
+		if l.value == "" {
+			continue
+		}
+		e, err := filter.NewComparison(l.field, l.op, l.value)
+		if err != nil {
+			return nil, invalidFilter(l.param, err)
+		}
+		exprs = append(exprs, e)
+	}
+	tags, err := q.tagsExpr()
+	if err != nil {
+		return nil, err
+	}
+	if q.Search != "" {
+		exprs = append(exprs, filter.Text{Value: q.Search})
+	}
+	fullText, err := filter.ParseSearch(q.FullText)
+	if err != nil {
+		return nil, invalidFilter("full_text", err)
+	}
+	return filter.Join(append(exprs, tags, fullText)...), nil
+}
+
+// tagsExpr mengubah Tags menjadi tag:nama yang digabung dengan OR atau AND
+// sesuai TagMatch.
+func (q TaskFilterQuery) tagsExpr() (filter.Expr, error) {
+	if q.Tags == "" {
+		return nil, nil
+	}
+	var tags []filter.Expr
+	for _, name := range strings.Split(q.Tags, ",") {
+		e, err := filter.NewComparison("tag", filter.OpEq, name)
+		if err != nil {
+			return nil, invalidFilter("tags", err)
+		}
+		tags = append(tags, e)
+	}
+	if q.TagMatch == "all" || len(tags) == 1 {
+		return filter.Join(tags...), nil
+	}
+	return filter.Or{Exprs: tags}, nil
+}
+
+// invalidFilter melaporkan kesalahan bahasa filter beserta posisinya.
+func invalidFilter(param string, err error) error {
+	var filterErr *filter.Error
+	if !errors.As(err, &filterErr) {
+		return apperrors.Validation("invalid_filter", err.Error()).Wrap(err)
+	}
+	return apperrors.Validation("invalid_filter", fmt.Sprintf("Invalid %s: %s at position %d", param, filterErr.Msg, filterErr.Pos)).
+		WithParams(param, filterErr.Msg, strconv.Itoa(filterErr.Pos)).
+		Wrap(err)
+}
+
 type GetAllTasksQuery struct {
-	Status string `form:"status"`
-	Page   int    `form:"page" binding:"omitempty,min=1"`
-	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
-	Search string `form:"search"`
+	TaskFilterQuery
+	Page  int `form:"page" binding:"omitempty,min=1"`
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+	// Sort berisi field dipisah koma dengan arah opsional, mis.
+	// "priority:desc,due_date". Arah bawaan adalah asc.
+	Sort string `form:"sort"`
+	// Cursor adalah next_cursor atau prev_cursor dari respons sebelumnya.
+	// Jika diisi, page diabaikan dan sort harus sama dengan saat cursor dibuat.
+	Cursor string `form:"cursor"`
+	// Count=false melewati perhitungan total_tasks dan total_pages, yang
+	// mahal untuk tabel besar.
+	Count *bool `form:"count"`
+	// Fuzzy mencari title yang mirip walau salah ketik, diurutkan menurut
+	// kemiripan. Tidak bisa digabung dengan full_text karena keduanya
+	// menentukan relevansi.
+	Fuzzy string `form:"fuzzy" binding:"omitempty,max=100"`
+	// View adalah ID saved view yang dipakai sebagai dasar query; tanpa
+	// parameter ini view bawaan user dipakai, "none" mengabaikannya.
+	View string `form:"view"`
+	// Archived "false" menyembunyikan task di project yang diarsipkan,
+	// "true" hanya menampilkannya, "all" menampilkan semuanya. Bawaannya
+	// false, kecuali di GET /api/projects/:id/tasks yang bawaannya all.
+	Archived string `form:"archived" binding:"omitempty,oneof=true false all"`
+}
+
+// scopeExpr mengembalikan filter project dari route bersarang dan filter
+// archived.
+func (q GetAllTasksQuery) scopeExpr(project *models.Project) filter.Expr {
+	archived := q.Archived
+	var exprs []filter.Expr
+	if project != nil {
+		exprs = append(exprs, filter.Comparison{Field: "project_id", Op: filter.OpEq, Value: int(project.ID)})
+		if archived == "" {
+			archived = "all"
+		}
+	}
+	if archived != "all" {
+		exprs = append(exprs, filter.Comparison{Field: "archived", Op: filter.OpEq, Value: archived == "true"})
+	}
+	return filter.Join(exprs...)
+}
+
+// applyView mengisi search, sort dan limit dari view jika tidak dikirim di
+// query, lalu mengembalikan filter view yang digabung dengan AND.
+func (q *GetAllTasksQuery) applyView(view models.View) (filter.Expr, error) {
+	where, err := filter.Parse(view.Filter)
+	if err != nil {
+		return nil, invalidFilter("view", err)
+	}
+	if q.Search == "" {
+		q.Search = view.Search
+	}
+	if q.Sort == "" {
+		q.Sort = view.Sort
+	}
+	if q.Limit == 0 {
+		q.Limit = view.PageSize
+	}
+	return where, nil
+}
+
+// resolveView mengembalikan view untuk parameter view: ID view, "none",
+// atau kosong untuk view bawaan user (nil jika tidak ada).
+func (tc *TaskController) resolveView(c *gin.Context, param string) (*models.View, error) {
+	ctx, username := c.Request.Context(), c.GetString("username")
+	switch param {
+	case noView:
+		return nil, nil
+	case "":
+		return tc.views.DefaultView(ctx, username)
+	}
+	id, err := viewID("view", param)
+	if err != nil {
+		return nil, err
+	}
+	return tc.views.GetView(ctx, username, id)
+}
+
+// where menggabungkan filter dengan pencarian fuzzy.
+func (q GetAllTasksQuery) where() (filter.Expr, error) {
+	where, err := q.expr()
+	if err != nil {
+		return nil, err
+	}
+	fuzzy := strings.TrimSpace(q.Fuzzy)
+	if fuzzy == "" {
+		return where, nil
+	}
+	if q.FullText != "" {
+		return nil, apperrors.Validation("validation_failed", "Request validation failed", apperrors.FieldError{
+			Field:   "fuzzy",
+			Rule:    "excluded_with",
+			Param:   "full_text",
+			Message: utils.FieldMessage("excluded_with", "full_text"),
+		})
+	}
+	return filter.Join(where, filter.Fuzzy{Value: fuzzy}), nil
+}
+
+// sortFields mem-parse Sort dan menolak field di luar
+// repositories.SortableFields atau field yang disebut dua kali. Jika ranked
+// (ada full_text atau fuzzy), relevance juga boleh dan menjadi urutan bawaan.
+func (q GetAllTasksQuery) sortFields(ranked bool) ([]repositories.SortField, error) {
+	if q.Sort == "" {
+		if ranked {
+			return []repositories.SortField{{Field: repositories.RelevanceSort, Desc: true}}, nil
+		}
+		return nil, nil
+	}
+
+	allowed := repositories.SortableFields()
+	if ranked {
+		allowed = append(allowed, repositories.RelevanceSort)
+	}
+	var fields []repositories.SortField
+	seen := make(map[string]bool)
+	for _, term := range strings.Split(q.Sort, ",") {
+		name, direction, _ := strings.Cut(strings.TrimSpace(term), ":")
+		if !slices.Contains(allowed, name) {
+			return nil, invalidSort("oneof", strings.Join(allowed, " "))
+		}
+		if direction != "" && direction != "asc" && direction != "desc" {
+			return nil, invalidSort("oneof", "asc desc")
+		}
+		if seen[name] {
+			return nil, invalidSort("invalid", "")
+		}
+		seen[name] = true
+		fields = append(fields, repositories.SortField{Field: name, Desc: direction == "desc"})
+	}
+	return fields, nil
+}
+
+func invalidSort(rule, param string) error {
+	return apperrors.Validation("validation_failed", "Request validation failed", apperrors.FieldError{
+		Field:   "sort",
+		Rule:    rule,
+		Param:   param,
+		Message: utils.FieldMessage(rule, param),
+	})
 }
 
 func (tc *TaskController) GetAllTasks(c *gin.Context) {
 	var query GetAllTasksQuery
 	if err := c.ShouldBindQuery(&query); err != nil {
 		tc.logger.Error("GetAllTasks: Invalid query parameters", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(utils.BindingError(err))
 		return
 	}
 
+	view, err := tc.resolveView(c, query.View)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	var viewWhere filter.Expr
+	if view != nil {
+		if viewWhere, err = query.applyView(*view); err != nil {
+			c.Error(err)
+			return
+		}
+	}
+
 	// Set default pagination
 	page := query.Page
 	if page == 0 {
@@ -91,28 +372,153 @@ func (tc *TaskController) GetAllTasks(c *gin.Context) {
 		limit = 10
 	}
 
-	filter := make(map[string]interface{})
-	if query.Status != "" {
-		filter["status"] = query.Status
+	where, err := query.where()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	where = filter.Join(viewWhere, where, query.scopeExpr(scopedProject(c)))
+
+	sort, err := query.sortFields(filter.Ranking(where) != nil)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	// Skor relevansi tidak tersimpan di baris sehingga tidak bisa menjadi
+	// kunci keyset; urutan relevansi hanya memakai mode halaman
+	relevance := slices.ContainsFunc(sort, func(s repositories.SortField) bool {
+		return s.Field == repositories.RelevanceSort
+	})
+
+	pagination := repositories.Pagination{
+		Page:      page,
+		Limit:     limit,
+		Sort:      sort,
+		SkipCount: query.Count != nil && !*query.Count,
+	}
+	if query.Cursor != "" {
+		if relevance {
+			c.Error(invalidCursor(errors.New("cursors are not supported with relevance sort")))
+			return
+		}
+		if err := tc.applyCursor(query.Cursor, &pagination); err != nil {
+			c.Error(err)
+			return
+		}
 	}
 
-	tasks, total, err := tc.service.GetAllTasks(filter, repositories.Pagination{Page: page, Limit: limit}, query.Search)
+	result, err := tc.service.GetAllTasks(c.Request.Context(), where, pagination)
 	if err != nil {
 		tc.logger.Error("GetAllTasks: Failed to retrieve tasks", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve tasks"})
+		c.Error(err)
 		return
 	}
 
-	totalPages := (total + int64(limit) - 1) / int64(limit)
+	items := make([]taskResponse, len(result.Tasks))
+	for i, task := range result.Tasks {
+		items[i] = taskResponse{Task: task, ETag: task.ETag()}
+		if hit, ok := result.Hits[task.ID]; ok {
+			items[i].Rank = &hit.Rank
+			items[i].Snippet = hit.Snippet
+		}
+	}
+
+	meta := gin.H{
+		"limit":    limit,
+		"has_next": result.HasNext,
+		"has_prev": result.HasPrev,
+	}
+	var totalPages int64
+	if result.Total >= 0 {
+		totalPages = (result.Total + int64(limit) - 1) / int64(limit)
+		meta["total_tasks"] = result.Total
+	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"tasks": tasks,
-		"pagination": gin.H{
-			"current_page": page,
-			"total_pages":  totalPages,
-			"total_tasks":  total,
-		},
-	})
+	// Cursor selalu disertakan agar client bisa beralih dari mode halaman
+	// ke mode cursor kapan saja
+	var nextCursor, prevCursor string
+	if len(result.Tasks) > 0 && !relevance {
+		if result.HasNext {
+			if nextCursor, err = tc.encodeCursor(result.Tasks[len(result.Tasks)-1], sort, false); err != nil {
+				c.Error(err)
+				return
+			}
+			meta["next_cursor"] = nextCursor
+		}
+		if result.HasPrev {
+			if prevCursor, err = tc.encodeCursor(result.Tasks[0], sort, true); err != nil {
+				c.Error(err)
+				return
+			}
+			meta["prev_cursor"] = prevCursor
+		}
+	}
+
+	if query.Cursor != "" {
+		links := map[string]string{"first": pageURL(c, map[string]string{"cursor": "", "page": ""})}
+		if nextCursor != "" {
+			links["next"] = pageURL(c, map[string]string{"cursor": nextCursor, "page": ""})
+		}
+		if prevCursor != "" {
+			links["prev"] = pageURL(c, map[string]string{"cursor": prevCursor, "page": ""})
+		}
+		setLinkHeader(c, links, "first", "prev", "next")
+	} else {
+		meta["current_page"] = page
+		if result.Total >= 0 {
+			meta["total_pages"] = totalPages
+		}
+		setLinkHeader(c, pageLinks(c, page, totalPages, result), "first", "prev", "next", "last")
+	}
+
+	response := gin.H{
+		"tasks":      items,
+		"pagination": meta,
+	}
+	if view != nil {
+		response["view"] = gin.H{"id": view.ID, "name": view.Name}
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// SuggestTitlesQuery adalah query GET /api/tasks/suggest untuk type-ahead.
+type SuggestTitlesQuery struct {
+	Prefix string `form:"prefix" binding:"required,max=100"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=20"`
+}
+
+type titleSuggestion struct {
+	ID    uint    `json:"id"`
+	Title string  `json:"title"`
+	Score float64 `json:"score"`
+}
+
+// SuggestTitles mengusulkan title task untuk prefix yang sedang diketik,
+// termasuk yang mirip jika prefix salah ketik.
+func (tc *TaskController) SuggestTitles(c *gin.Context) {
+	var query SuggestTitlesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		tc.logger.Error("SuggestTitles: Invalid query parameters", err)
+		c.Error(utils.BindingError(err))
+		return
+	}
+	limit := query.Limit
+	if limit == 0 {
+		limit = 5
+	}
+
+	result, err := tc.service.SuggestTitles(c.Request.Context(), strings.TrimSpace(query.Prefix), limit)
+	if err != nil {
+		tc.logger.Error("SuggestTitles: Failed to suggest titles", err)
+		c.Error(err)
+		return
+	}
+
+	suggestions := make([]titleSuggestion, len(result))
+	for i, s := range result {
+		suggestions[i] = titleSuggestion{ID: s.ID, Title: s.Title, Score: s.Score}
+	}
+	c.JSON(http.StatusOK, gin.H{"suggestions": suggestions})
 }
 
 func (tc *TaskController) GetTaskByID(c *gin.Context) {
@@ -120,25 +526,65 @@ func (tc *TaskController) GetTaskByID(c *gin.Context) {
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
 		tc.logger.Error("GetTaskByID: Invalid ID", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		c.Error(invalidTaskID(err))
 		return
 	}
 
-	task, err := tc.service.GetTaskByID(uint(id))
+	task, err := tc.service.GetTaskByID(c.Request.Context(), uint(id))
 	if err != nil {
-		tc.logger.Error("GetTaskByID: Task not found", err)
-		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
+		tc.logger.Error("GetTaskByID: Failed to retrieve task", err)
+		c.Error(err)
+		return
+	}
+
+	etag := task.ETag()
+	c.Header("ETag", etag)
+	if noneMatch(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
 		return
 	}
 
 	c.JSON(http.StatusOK, task)
 }
 
+// taskResponse menambahkan etag ke setiap task di daftar agar client bisa
+// langsung mengirim If-Match tanpa GET per task. Rank dan Snippet hanya
+// diisi saat pencarian full-text; Snippet berupa HTML dengan <mark>.
+type taskResponse struct {
+	models.Task
+	ETag    string   `json:"etag"`
+	Rank    *float64 `json:"rank,omitempty"`
+	Snippet string   `json:"snippet,omitempty"`
+}
+
+// UpdateTaskInput adalah representasi lengkap task untuk PUT. PUT mengganti
+// seluruh task: field opsional yang tidak dikirim (description) dikosongkan.
+// PATCH menerapkan patch ke dokumen yang sama lalu memvalidasinya dengan
+// aturan ini juga.
 type UpdateTaskInput struct {
-	Title       string `json:"title"`
+	Title       string `json:"title" binding:"required"`
 	Description string `json:"description"`
-	Status      string `json:"status" binding:"omitempty,oneof=pending completed"`
-	DueDate     string `json:"due_date" binding:"omitempty,datetime=2006-01-02"`
+	Status      string `json:"status" binding:"required,oneof=pending completed"`
+	DueDate     string `json:"due_date" binding:"required,datetime=2006-01-02"`
+	Priority    int    `json:"priority" binding:"min=0,max=3"`
+	// AutoComplete ikut diganti oleh PUT; tanpa field ini nilainya false.
+	AutoComplete bool `json:"auto_complete"`
+}
+
+// apply menyalin input ke task; dipakai oleh PUT dan PATCH.
+func (input UpdateTaskInput) apply(task *models.Task) error {
+	dueDate, err := time.Parse("2006-01-02", input.DueDate)
+	if err != nil {
+		return invalidDueDate(err)
+	}
+
+	task.Title = input.Title
+	task.Description = input.Description
+	task.Status = input.Status
+	task.DueDate = dueDate
+	task.Priority = input.Priority
+	task.AutoComplete = input.AutoComplete
+	return nil
 }
 
 func (tc *TaskController) UpdateTask(c *gin.Context) {
@@ -146,64 +592,262 @@ func (tc *TaskController) UpdateTask(c *gin.Context) {
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
 		tc.logger.Error("UpdateTask: Invalid ID", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		c.Error(invalidTaskID(err))
 		return
 	}
 
 	var input UpdateTaskInput
 	if err := c.ShouldBindJSON(&input); err != nil {
 		tc.logger.Error("UpdateTask: Invalid input", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(utils.BindingError(err))
+		return
+	}
+
+	version, err := ifMatchVersion(c, uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	updatedTask := models.Task{Version: version}
+	if err := input.apply(&updatedTask); err != nil {
+		tc.logger.Error("UpdateTask: Invalid due_date format", err)
+		c.Error(err)
 		return
 	}
 
-	var dueDate time.Time
-	if input.DueDate != "" {
-		dueDate, err = time.Parse("2006-01-02", input.DueDate)
+	if err := tc.service.UpdateTask(c.Request.Context(), uint(id), &updatedTask); err != nil {
+		tc.logger.Error("UpdateTask: Failed to update task", err)
+		c.Error(err)
+		return
+	}
+
+	task, err := tc.service.GetTaskByID(c.Request.Context(), uint(id))
+	if err != nil {
+		tc.logger.Error("UpdateTask: Failed to reload task", err)
+		c.Error(err)
+		return
+	}
+
+	c.Header("ETag", task.ETag())
+	c.JSON(http.StatusOK, gin.H{
+		"message": message(c, "task.updated", "Task updated successfully"),
+		"task":    task,
+	})
+}
+
+const (
+	mergePatchContentType = "application/merge-patch+json"
+	jsonPatchContentType  = "application/json-patch+json"
+)
+
+// PatchTask mendukung JSON Merge Patch (RFC 7396) dan JSON Patch (RFC 6902).
+// Dengan merge patch, {"description": null} mengosongkan description.
+// application/json diperlakukan sebagai merge patch.
+func (tc *TaskController) PatchTask(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		tc.logger.Error("PatchTask: Invalid ID", err)
+		c.Error(invalidTaskID(err))
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		tc.logger.Error("PatchTask: Failed to read body", err)
+		c.Error(utils.BindingError(err))
+		return
+	}
+
+	var applyPatch func(doc []byte) ([]byte, error)
+	switch c.ContentType() {
+	case mergePatchContentType, "application/json":
+		applyPatch = func(doc []byte) ([]byte, error) {
+			return jsonpatch.MergePatch(doc, body)
+		}
+	case jsonPatchContentType:
+		patch, err := jsonpatch.DecodePatch(body)
 		if err != nil {
-			tc.logger.Error("UpdateTask: Invalid due_date format", err)
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid due_date format"})
+			tc.logger.Error("PatchTask: Invalid JSON Patch", err)
+			c.Error(apperrors.Validation("invalid_patch", "Patch document is not valid").Wrap(err))
 			return
 		}
+		applyPatch = patch.Apply
+	default:
+		c.Header("Accept-Patch", mergePatchContentType+", "+jsonPatchContentType)
+		c.Error(apperrors.UnsupportedMediaType("unsupported_patch_type", "Use "+mergePatchContentType+" or "+jsonPatchContentType).
+			WithParams(mergePatchContentType, jsonPatchContentType))
+		return
 	}
 
-	updatedTask := models.Task{
-		Title:       input.Title,
-		Description: input.Description,
-		Status:      input.Status,
-		DueDate:     dueDate,
+	version, err := ifMatchVersion(c, uint(id))
+	if err != nil {
+		c.Error(err)
+		return
 	}
 
-	if err := tc.service.UpdateTask(uint(id), &updatedTask); err != nil {
-		tc.logger.Error("UpdateTask: Failed to update task", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update task"})
+	task, err := tc.service.PatchTask(c.Request.Context(), uint(id), version, func(task *models.Task) error {
+		doc, err := json.Marshal(UpdateTaskInput{
+			Title:        task.Title,
+			Description:  task.Description,
+			Status:       task.Status,
+			DueDate:      task.DueDate.Format("2006-01-02"),
+			Priority:     task.Priority,
+			AutoComplete: task.AutoComplete,
+		})
+		if err != nil {
+			return err
+		}
+
+		patched, err := applyPatch(doc)
+		if err != nil {
+			return patchError(err)
+		}
+
+		// Patch tidak boleh menambah field di luar dokumen, mis. "id"
+		var input UpdateTaskInput
+		decoder := json.NewDecoder(bytes.NewReader(patched))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&input); err != nil {
+			return apperrors.Validation("invalid_patch", "Patch document is not valid").Wrap(err)
+		}
+		if err := binding.Validator.ValidateStruct(&input); err != nil {
+			return utils.BindingError(err)
+		}
+		return input.apply(task)
+	})
+	if err != nil {
+		tc.logger.Error("PatchTask: Failed to patch task", err)
+		c.Error(err)
 		return
 	}
 
-	task, _ := tc.service.GetTaskByID(uint(id))
-
+	c.Header("ETag", task.ETag())
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Task updated successfully",
+		"message": message(c, "task.updated", "Task updated successfully"),
 		"task":    task,
 	})
 }
 
+// patchError membedakan operasi "test" yang gagal (409) dari patch yang
+// tidak bisa diterapkan ke dokumen, mis. path tidak ada (400).
+func patchError(err error) error {
+	if errors.Is(err, jsonpatch.ErrTestFailed) {
+		return apperrors.Conflict("patch_test_failed", "JSON Patch test operation failed").Wrap(err)
+	}
+	return apperrors.Validation("invalid_patch", "Patch document is not valid").Wrap(err)
+}
+
 func (tc *TaskController) DeleteTask(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
 		tc.logger.Error("DeleteTask: Invalid ID", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+		c.Error(invalidTaskID(err))
 		return
 	}
 
-	if err := tc.service.DeleteTask(uint(id)); err != nil {
+	version, err := ifMatchVersion(c, uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := tc.service.DeleteTask(c.Request.Context(), uint(id), version); err != nil {
 		tc.logger.Error("DeleteTask: Failed to delete task", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete task"})
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Task deleted successfully",
+		"message": message(c, "task.deleted", "Task deleted successfully"),
 	})
 }
+
+// ifMatchVersion membaca versi yang diharapkan dari header If-Match. Header
+// kosong atau "*" berarti tanpa pemeriksaan (0). Selain itu header harus
+// berisi satu ETag kuat milik task id; ETag lemah, milik task lain atau
+// tidak dikenali tidak akan pernah cocok sehingga langsung 412.
+func ifMatchVersion(c *gin.Context, id uint) (uint, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	mismatch := apperrors.PreconditionFailed("task_version_mismatch", fmt.Sprintf("Task %d was modified by someone else", id)).
+		WithParams(strconv.FormatUint(uint64(id), 10))
+
+	tag, ok := strings.CutPrefix(header, `"`)
+	if !ok {
+		return 0, mismatch
+	}
+	tag, ok = strings.CutSuffix(tag, `"`)
+	if !ok {
+		return 0, mismatch
+	}
+	idPart, versionPart, ok := strings.Cut(tag, "-")
+	if !ok || idPart != strconv.FormatUint(uint64(id), 10) {
+		return 0, mismatch
+	}
+	version, err := strconv.ParseUint(versionPart, 10, 32)
+	if err != nil || version == 0 {
+		return 0, mismatch
+	}
+	return uint(version), nil
+}
+
+// noneMatch melaporkan apakah header If-None-Match cocok dengan etag
+// (perbandingan lemah, RFC 9110 13.1.2) sehingga cukup dibalas 304.
+func noneMatch(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// pathID mem-parse ID positif dari path atau parameter field. code dan
+// message adalah error Validation jika value bukan ID yang valid.
+func pathID(field, value, code, message string) (uint, error) {
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil || id == 0 {
+		return 0, apperrors.Validation(code, message, apperrors.FieldError{
+			Field:   field,
+			Rule:    "type",
+			Param:   "uint",
+			Message: utils.FieldMessage("type", "uint"),
+		})
+	}
+	return uint(id), nil
+}
+
+// taskID mem-parse ID task dari path.
+func taskID(field, value string) (uint, error) {
+	return pathID(field, value, "invalid_task_id", "Task ID must be a positive integer")
+}
+
+func invalidTaskID(err error) error {
+	return apperrors.Validation("invalid_task_id", "Task ID must be a positive integer", apperrors.FieldError{
+		Field:   "id",
+		Rule:    "type",
+		Param:   "uint",
+		Message: utils.FieldMessage("type", "uint"),
+	}).Wrap(err)
+}
+
+func invalidDueDate(err error) error {
+	return apperrors.Validation("invalid_due_date", "Invalid due_date format", apperrors.FieldError{
+		Field:   "due_date",
+		Rule:    "datetime",
+		Param:   "2006-01-02",
+		Message: utils.FieldMessage("datetime", "2006-01-02"),
+	}).Wrap(err)
+}
+
+// message menerjemahkan pesan sukses sesuai locale request.
+func message(c *gin.Context, key, fallback string) string {
+	return i18n.Message(c.GetString(i18n.ContextKey), key, fallback)
+}
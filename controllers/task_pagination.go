@@ -0,0 +1,119 @@
+// controllers/task_pagination.go
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/programmercintasunnah/go-todolist-ilcs/utils"
+)
+
+// cursorPayload adalah isi token next_cursor/prev_cursor: kunci sort baris
+// batas beserta sort yang dipakai, agar cursor tidak dipakai dengan urutan
+// lain.
+type cursorPayload struct {
+	Sort     string          `json:"s"`
+	Key      json.RawMessage `json:"k"`
+	Backward bool            `json:"b,omitempty"`
+}
+
+// sortString mengembalikan bentuk kanonik sort, mis. "priority:desc,id:asc".
+func sortString(sort []repositories.SortField) string {
+	terms := make([]string, 0, len(sort)+1)
+	for _, s := range sort {
+		direction := ":asc"
+		if s.Desc {
+			direction = ":desc"
+		}
+		terms = append(terms, s.Field+direction)
+	}
+	return strings.Join(append(terms, "id:asc"), ",")
+}
+
+// encodeCursor membuat cursor untuk halaman sesudah task (atau sebelum jika
+// backward).
+func (tc *TaskController) encodeCursor(task models.Task, sort []repositories.SortField, backward bool) (string, error) {
+	key, err := json.Marshal(repositories.SortKey(task, sort))
+	if err != nil {
+		return "", err
+	}
+	return utils.SignCursor(cursorPayload{Sort: sortString(sort), Key: key, Backward: backward}, tc.cursorSecret)
+}
+
+// applyCursor memverifikasi token lalu mengisi pagination.After atau Before.
+func (tc *TaskController) applyCursor(token string, pagination *repositories.Pagination) error {
+	var payload cursorPayload
+	if err := utils.ParseCursor(token, tc.cursorSecret, &payload); err != nil {
+		return invalidCursor(err)
+	}
+	if payload.Sort != sortString(pagination.Sort) {
+		return invalidCursor(fmt.Errorf("cursor was issued for sort %q", payload.Sort))
+	}
+
+	var boundary models.Task
+	if err := json.Unmarshal(payload.Key, &boundary); err != nil {
+		return invalidCursor(err)
+	}
+	if payload.Backward {
+		pagination.Before = &boundary
+	} else {
+		pagination.After = &boundary
+	}
+	return nil
+}
+
+func invalidCursor(err error) error {
+	return apperrors.Validation("invalid_cursor", "Cursor is invalid or was issued for a different sort").Wrap(err)
+}
+
+// pageURL mengembalikan URL request saat ini dengan parameter query yang
+// diganti; nilai kosong menghapus parameter.
+func pageURL(c *gin.Context, params map[string]string) string {
+	query := c.Request.URL.Query()
+	for key, value := range params {
+		if value == "" {
+			query.Del(key)
+		} else {
+			query.Set(key, value)
+		}
+	}
+	if len(query) == 0 {
+		return c.Request.URL.Path
+	}
+	return c.Request.URL.Path + "?" + query.Encode()
+}
+
+// setLinkHeader menulis header Link (RFC 8288) dengan rel sesuai urutan
+// rels; rel yang URL-nya kosong dilewati.
+func setLinkHeader(c *gin.Context, links map[string]string, rels ...string) {
+	var values []string
+	for _, rel := range rels {
+		if url := links[rel]; url != "" {
+			values = append(values, fmt.Sprintf(`<%s>; rel="%s"`, url, rel))
+		}
+	}
+	if len(values) > 0 {
+		c.Header("Link", strings.Join(values, ", "))
+	}
+}
+
+// pageLinks menyusun link first/prev/next/last untuk mode halaman.
+func pageLinks(c *gin.Context, page int, totalPages int64, result *repositories.TaskPage) map[string]string {
+	links := map[string]string{"first": pageURL(c, map[string]string{"page": "1"})}
+	if result.HasPrev {
+		links["prev"] = pageURL(c, map[string]string{"page": strconv.Itoa(page - 1)})
+	}
+	if result.HasNext {
+		links["next"] = pageURL(c, map[string]string{"page": strconv.Itoa(page + 1)})
+	}
+	if totalPages > 0 {
+		links["last"] = pageURL(c, map[string]string{"page": strconv.FormatInt(totalPages, 10)})
+	}
+	return links
+}
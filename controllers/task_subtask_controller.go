@@ -0,0 +1,80 @@
+// controllers/task_subtask_controller.go
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/utils"
+)
+
+// SetParentInput adalah body PUT /api/tasks/:id/parent; parent_id null
+// menjadikan task tingkat atas.
+type SetParentInput struct {
+	ParentID *uint `json:"parent_id" binding:"omitempty,min=1"`
+}
+
+// GetSubtasks mengembalikan subtask langsung dari task beserta progress
+// parent yang dihitung darinya.
+func (tc *TaskController) GetSubtasks(c *gin.Context) {
+	id, err := taskID("id", c.Param("id"))
+	if err != nil {
+		tc.logger.Error("GetSubtasks: Invalid ID", err)
+		c.Error(err)
+		return
+	}
+
+	subtasks, err := tc.service.GetSubtasks(c.Request.Context(), id)
+	if err != nil {
+		tc.logger.Error("GetSubtasks: Failed to retrieve subtasks", err)
+		c.Error(err)
+		return
+	}
+
+	items := make([]taskResponse, len(subtasks))
+	for i, task := range subtasks {
+		items[i] = taskResponse{Task: task, ETag: task.ETag()}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"subtasks": items,
+		"progress": models.SubtaskProgress(subtasks),
+	})
+}
+
+// SetParent memindahkan task ke bawah task lain. If-Match berlaku seperti
+// pada update task karena parent termasuk representasi task.
+func (tc *TaskController) SetParent(c *gin.Context) {
+	id, err := taskID("id", c.Param("id"))
+	if err != nil {
+		tc.logger.Error("SetParent: Invalid ID", err)
+		c.Error(err)
+		return
+	}
+
+	var input SetParentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		tc.logger.Error("SetParent: Invalid input", err)
+		c.Error(utils.BindingError(err))
+		return
+	}
+
+	version, err := ifMatchVersion(c, id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	task, err := tc.service.SetParent(c.Request.Context(), id, version, input.ParentID)
+	if err != nil {
+		tc.logger.Error("SetParent: Failed to change parent", err)
+		c.Error(err)
+		return
+	}
+
+	c.Header("ETag", task.ETag())
+	c.JSON(http.StatusOK, gin.H{
+		"message": message(c, "task.updated", "Task updated successfully"),
+		"task":    task,
+	})
+}
@@ -0,0 +1,227 @@
+// controllers/view_controller.go
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/programmercintasunnah/go-todolist-ilcs/filter"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/services"
+	"github.com/programmercintasunnah/go-todolist-ilcs/utils"
+	"github.com/sirupsen/logrus"
+)
+
+type ViewController struct {
+	service services.ViewService
+	logger  *logrus.Logger
+}
+
+func NewViewController(service services.ViewService, logger *logrus.Logger) *ViewController {
+	return &ViewController{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// ViewInput adalah body POST dan PUT /api/views. Filter, Search, Sort dan
+// PageSize sama artinya dengan parameter q, search, sort dan limit di
+// GET /api/tasks. Default menjadikan view ini view bawaan pembuatnya.
+type ViewInput struct {
+	Name       string   `json:"name" binding:"required,max=100"`
+	Filter     string   `json:"filter" binding:"max=1000"`
+	Search     string   `json:"search" binding:"max=255"`
+	Sort       string   `json:"sort" binding:"max=255"`
+	PageSize   int      `json:"page_size" binding:"min=0,max=100"`
+	SharedWith []string `json:"shared_with" binding:"max=50,dive,required,max=100"`
+	Default    bool     `json:"default"`
+}
+
+// view memeriksa filter, search dan sort dengan aturan GET /api/tasks agar
+// view yang tersimpan selalu bisa dipakai.
+func (input ViewInput) view() (*models.View, error) {
+	where, err := filter.Parse(input.Filter)
+	if err != nil {
+		return nil, invalidFilter("filter", err)
+	}
+	query := GetAllTasksQuery{Sort: input.Sort}
+	if _, err := query.sortFields(filter.Ranking(where) != nil); err != nil {
+		return nil, err
+	}
+
+	return &models.View{
+		Name:       input.Name,
+		Filter:     input.Filter,
+		Search:     input.Search,
+		Sort:       input.Sort,
+		PageSize:   input.PageSize,
+		SharedWith: input.SharedWith,
+	}, nil
+}
+
+func (vc *ViewController) CreateView(c *gin.Context) {
+	var input ViewInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		vc.logger.Error("CreateView: Invalid input", err)
+		c.Error(utils.BindingError(err))
+		return
+	}
+	view, err := input.view()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	ctx, username := c.Request.Context(), c.GetString("username")
+	if err := vc.service.CreateView(ctx, username, view); err != nil {
+		vc.logger.Error("CreateView: Failed to create view", err)
+		c.Error(err)
+		return
+	}
+	if input.Default {
+		if err := vc.service.SetDefaultView(ctx, username, view.ID); err != nil {
+			vc.logger.Error("CreateView: Failed to set default view", err)
+			c.Error(err)
+			return
+		}
+		view.IsDefault = true
+	}
+
+	c.Header("Location", strings.TrimSuffix(c.Request.URL.Path, "/")+"/"+strconv.FormatUint(uint64(view.ID), 10))
+	c.JSON(http.StatusCreated, gin.H{
+		"message": message(c, "view.created", "View created successfully"),
+		"view":    view,
+	})
+}
+
+// ListViews mengembalikan view milik user dan view yang dibagikan kepadanya.
+func (vc *ViewController) ListViews(c *gin.Context) {
+	views, err := vc.service.ListViews(c.Request.Context(), c.GetString("username"))
+	if err != nil {
+		vc.logger.Error("ListViews: Failed to retrieve views", err)
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"views": views})
+}
+
+func (vc *ViewController) GetView(c *gin.Context) {
+	id, err := viewID("id", c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	view, err := vc.service.GetView(c.Request.Context(), c.GetString("username"), id)
+	if err != nil {
+		vc.logger.Error("GetView: Failed to retrieve view", err)
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, view)
+}
+
+// UpdateView mengganti seluruh view; hanya owner yang boleh. Default false
+// melepas view ini jika sebelumnya menjadi view bawaan owner.
+func (vc *ViewController) UpdateView(c *gin.Context) {
+	id, err := viewID("id", c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var input ViewInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		vc.logger.Error("UpdateView: Invalid input", err)
+		c.Error(utils.BindingError(err))
+		return
+	}
+	view, err := input.view()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	view.ID = id
+
+	ctx, username := c.Request.Context(), c.GetString("username")
+	if err := vc.service.UpdateView(ctx, username, view); err != nil {
+		vc.logger.Error("UpdateView: Failed to update view", err)
+		c.Error(err)
+		return
+	}
+	if input.Default {
+		err = vc.service.SetDefaultView(ctx, username, id)
+	} else {
+		err = vc.service.ClearDefaultView(ctx, username, id)
+	}
+	if err != nil {
+		vc.logger.Error("UpdateView: Failed to update default view", err)
+		c.Error(err)
+		return
+	}
+	view.IsDefault = input.Default
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": message(c, "view.updated", "View updated successfully"),
+		"view":    view,
+	})
+}
+
+func (vc *ViewController) DeleteView(c *gin.Context) {
+	id, err := viewID("id", c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := vc.service.DeleteView(c.Request.Context(), c.GetString("username"), id); err != nil {
+		vc.logger.Error("DeleteView: Failed to delete view", err)
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message(c, "view.deleted", "View deleted successfully"),
+	})
+}
+
+// SetDefaultView menjadikan view (termasuk view yang dibagikan) view bawaan
+// GET /api/tasks untuk user.
+func (vc *ViewController) SetDefaultView(c *gin.Context) {
+	id, err := viewID("id", c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := vc.service.SetDefaultView(c.Request.Context(), c.GetString("username"), id); err != nil {
+		vc.logger.Error("SetDefaultView: Failed to set default view", err)
+		c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (vc *ViewController) ClearDefaultView(c *gin.Context) {
+	id, err := viewID("id", c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := vc.service.ClearDefaultView(c.Request.Context(), c.GetString("username"), id); err != nil {
+		vc.logger.Error("ClearDefaultView: Failed to clear default view", err)
+		c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// noView adalah nilai parameter view untuk mengabaikan view bawaan.
+const noView = "none"
+
+// viewID mem-parse ID view dari path atau parameter field.
+func viewID(field, value string) (uint, error) {
+	return pathID(field, value, "invalid_view_id", "View ID must be a positive integer or none")
+}
@@ -0,0 +1,127 @@
+// filter/ast.go
+package filter
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Expr adalah node AST ekspresi filter task. nil berarti cocok dengan semua
+// task. Implementasinya: And, Or, Not, Comparison, Text, Search dan Fuzzy.
+type Expr interface {
+	expr()
+	String() string
+}
+
+// And cocok jika semua Exprs cocok. Term yang dipisah spasi digabung And.
+type And struct {
+	Exprs []Expr
+}
+
+// Or cocok jika salah satu Exprs cocok (kata kunci OR).
+type Or struct {
+	Exprs []Expr
+}
+
+// Not membalik Expr (awalan "-").
+type Not struct {
+	Expr Expr
+}
+
+// Op adalah operator perbandingan. OpEq untuk field teks berarti "mengandung"
+// (tanpa membedakan huruf besar/kecil).
+type Op string
+
+const (
+	OpEq  Op = ":"
+	OpLt  Op = "<"
+	OpLte Op = "<="
+	OpGt  Op = ">"
+	OpGte Op = ">="
+)
+
+// Comparison membandingkan Field dengan Value. Field adalah nama kolom
+// (mis. "due_date") dan Value sudah bertipe sesuai field: string, int, bool
+// atau time.Time. Untuk project_id dan parent_id, nilai 0 berarti tidak ada
+// (task tanpa project atau task tingkat atas).
+type Comparison struct {
+	Field string
+	Op    Op
+	Value interface{}
+}
+
+// Text adalah kata tanpa field; cocok jika title atau description
+// mengandung Value.
+type Text struct {
+	Value string
+}
+
+func (And) expr()        {}
+func (Or) expr()         {}
+func (Not) expr()        {}
+func (Comparison) expr() {}
+func (Text) expr()       {}
+
+func (e And) String() string { return joinExprs(e.Exprs, " ") }
+func (e Or) String() string  { return joinExprs(e.Exprs, " OR ") }
+func (e Not) String() string { return "-" + group(e.Expr) }
+
+func (e Comparison) String() string {
+	var value string
+	switch v := e.Value.(type) {
+	case time.Time:
+		value = v.Format(dateLayout)
+	case int:
+		value = strconv.Itoa(v)
+	case bool:
+		value = strconv.FormatBool(v)
+	case string:
+		value = strconv.Quote(v)
+	}
+	return e.Field + string(e.Op) + value
+}
+
+func (e Text) String() string { return strconv.Quote(e.Value) }
+
+func joinExprs(exprs []Expr, sep string) string {
+	parts := make([]string, len(exprs))
+	for i, e := range exprs {
+		parts[i] = group(e)
+	}
+	return strings.Join(parts, sep)
+}
+
+// group menambahkan kurung di sekitar And dan Or agar String bisa di-parse
+// ulang dengan arti yang sama.
+func group(e Expr) string {
+	switch e.(type) {
+	case And, Or:
+		return "(" + e.String() + ")"
+	}
+	return e.String()
+}
+
+// Join menggabungkan exprs dengan And, melewati nil. Hasilnya nil jika
+// tidak ada expr yang tersisa.
+func Join(exprs ...Expr) Expr {
+	var terms []Expr
+	for _, e := range exprs {
+		switch e := e.(type) {
+		case nil:
+		case And:
+			// And bertingkat diratakan agar Ranking dan splitFuzzy tetap
+			// menemukan node pencarian di tingkat teratas
+			terms = append(terms, e.Exprs...)
+		default:
+			terms = append(terms, e)
+		}
+	}
+	switch len(terms) {
+	case 0:
+		return nil
+	case 1:
+		return terms[0]
+	}
+	return And{Exprs: terms}
+}
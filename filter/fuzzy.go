@@ -0,0 +1,102 @@
+// filter/fuzzy.go
+package filter
+
+import (
+	"strconv"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+)
+
+// FuzzyThreshold adalah kemiripan minimum agar title cocok dengan Fuzzy. Di
+// Postgres nilai ini dipasang sebagai pg_trgm.word_similarity_threshold
+// (lihat repositories.PostgresOptions).
+const FuzzyThreshold = 0.3
+
+// Fuzzy cocok jika title mirip dengan Value walau ada salah ketik, diukur
+// dengan WordSimilarity (parameter fuzzy).
+type Fuzzy struct {
+	Value string
+}
+
+func (Fuzzy) expr() {}
+
+func (e Fuzzy) String() string { return "fuzzy(" + strconv.Quote(e.Value) + ")" }
+
+// WordSimilarity meniru word_similarity(query, text) dari pg_trgm: kemiripan
+// trigram terbesar antara query dan potongan berurutan dari text, antara 0
+// dan 1. Dipakai untuk dialek tanpa pg_trgm dan untuk data di memori.
+func WordSimilarity(query, text string) float64 {
+	q := make(map[string]bool)
+	for _, t := range trigrams(query) {
+		q[t] = true
+	}
+	if len(q) == 0 {
+		return 0
+	}
+
+	// Potongan terbaik selalu diawali dan diakhiri trigram milik query
+	t := trigrams(text)
+	var best float64
+	for i := range t {
+		if !q[t[i]] {
+			continue
+		}
+		seen := make(map[string]bool)
+		shared := 0
+		for j := i; j < len(t); j++ {
+			if !seen[t[j]] {
+				seen[t[j]] = true
+				if q[t[j]] {
+					shared++
+				}
+			}
+			if q[t[j]] {
+				best = max(best, float64(shared)/float64(len(q)+len(seen)-shared))
+			}
+		}
+	}
+	return best
+}
+
+// trigrams mengembalikan trigram berurutan dari setiap kata dengan cara
+// pg_trgm: huruf kecil, dua spasi di depan dan satu di belakang kata.
+func trigrams(s string) []string {
+	var out []string
+	for _, word := range searchWords(s) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			out = append(out, string(padded[i:i+3]))
+		}
+	}
+	return out
+}
+
+// Ranking mengembalikan node yang menentukan relevansi e: Search atau Fuzzy
+// di puncak e atau salah satu anggota And di puncak; nil jika tidak ada.
+// Node yang sama di dalam Or atau Not tetap menyaring tetapi tidak dipakai
+// untuk peringkat.
+func Ranking(e Expr) Expr {
+	switch e := e.(type) {
+	case Search, Fuzzy:
+		return e
+	case And:
+		for _, sub := range e.Exprs {
+			switch sub.(type) {
+			case Search, Fuzzy:
+				return sub
+			}
+		}
+	}
+	return nil
+}
+
+// Relevance menghitung relevansi task di memori untuk node hasil Ranking.
+func Relevance(ranking Expr, task models.Task) float64 {
+	switch r := ranking.(type) {
+	case Search:
+		return Score(r, task)
+	case Fuzzy:
+		return WordSimilarity(r.Value, task.Title)
+	}
+	return 0
+}
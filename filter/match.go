@@ -0,0 +1,102 @@
+// filter/match.go
+package filter
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+)
+
+// Match mengevaluasi e terhadap task di memori dengan arti yang sama seperti
+// SQL hasil kompilasi repository. e nil cocok dengan semua task.
+func Match(e Expr, task models.Task) bool {
+	switch e := e.(type) {
+	case nil:
+		return true
+	case And:
+		for _, sub := range e.Exprs {
+			if !Match(sub, task) {
+				return false
+			}
+		}
+		return true
+	case Or:
+		for _, sub := range e.Exprs {
+			if Match(sub, task) {
+				return true
+			}
+		}
+		return false
+	case Not:
+		return !Match(e.Expr, task)
+	case Text:
+		return containsFold(task.Title, e.Value) || containsFold(task.Description, e.Value)
+	case Comparison:
+		return matchComparison(e, task)
+	case Search:
+		return matchSearch(e, task)
+	case Fuzzy:
+		return WordSimilarity(e.Value, task.Title) >= FuzzyThreshold
+	}
+	return false
+}
+
+func matchComparison(c Comparison, task models.Task) bool {
+	switch c.Field {
+	case "title":
+		return containsFold(task.Title, c.Value.(string))
+	case "description":
+		return containsFold(task.Description, c.Value.(string))
+	case "status":
+		return task.Status == c.Value.(string)
+	case "priority":
+		return compareOp(cmp.Compare(task.Priority, c.Value.(int)), c.Op)
+	case "due_date":
+		return compareOp(task.DueDate.Compare(c.Value.(time.Time)), c.Op)
+	case "created_at":
+		return compareOp(task.CreatedAt.Compare(c.Value.(time.Time)), c.Op)
+	case "updated_at":
+		return compareOp(task.UpdatedAt.Compare(c.Value.(time.Time)), c.Op)
+	case "project_id":
+		return matchRef(task.ProjectID, c.Value.(int))
+	case "parent_id":
+		return matchRef(task.ParentID, c.Value.(int))
+	case "archived":
+		return task.Archived == c.Value.(bool)
+	case "tag":
+		return slices.ContainsFunc(task.Tags, func(tag models.Tag) bool { return tag.Name == c.Value.(string) })
+	}
+	return false
+}
+
+// matchRef mencocokkan ID opsional; id 0 berarti tidak ada.
+func matchRef(ref *uint, id int) bool {
+	if id == 0 {
+		return ref == nil
+	}
+	return ref != nil && *ref == uint(id)
+}
+
+// compareOp menerapkan op pada hasil perbandingan tiga arah.
+func compareOp(c int, op Op) bool {
+	switch op {
+	case OpEq:
+		return c == 0
+	case OpLt:
+		return c < 0
+	case OpLte:
+		return c <= 0
+	case OpGt:
+		return c > 0
+	case OpGte:
+		return c >= 0
+	}
+	return false
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
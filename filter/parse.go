@@ -0,0 +1,419 @@
+// filter/parse.go
+package filter
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+)
+
+// Bahasa filter:
+//
+//	status:pending due<2026-11-01 -title:draft
+//	(priority>=2 OR due<=2026-11-01) "release notes"
+//
+// Term dipisah spasi digabung dengan AND; OR mengikat lebih lemah dari AND;
+// "-" di depan term atau kurung membalik artinya. Term berbentuk
+// field<op>nilai dengan op salah satu : < <= > >=, atau hanya kata (boleh
+// dalam tanda kutip) yang dicari di title dan description. tag:nama cocok
+// dengan task yang memiliki tag tersebut; project:ID dengan task di project
+// itu, project:none dengan task tanpa project. parent:ID dan parent:none
+// sama untuk subtask dan task tingkat atas.
+
+const dateLayout = "2006-01-02"
+
+// maxDepth membatasi kurung dan negasi bertingkat agar input tidak
+// menghabiskan stack.
+const maxDepth = 20
+
+type kind int
+
+const (
+	kindText kind = iota
+	kindEnum
+	kindInt
+	kindDate
+	kindTag
+	kindBool
+	kindRef
+)
+
+type field struct {
+	column string
+	kind   kind
+	values []string // nilai yang diizinkan untuk kindEnum
+	min    int      // batas untuk kindInt
+	max    int
+}
+
+// fields adalah field yang dikenal beserta aliasnya.
+var fields = map[string]field{
+	"status":      {column: "status", kind: kindEnum, values: []string{"pending", "completed"}},
+	"title":       {column: "title", kind: kindText},
+	"description": {column: "description", kind: kindText},
+	"priority":    {column: "priority", kind: kindInt, min: 0, max: 3},
+	"due":         {column: "due_date", kind: kindDate},
+	"due_date":    {column: "due_date", kind: kindDate},
+	"created":     {column: "created_at", kind: kindDate},
+	"created_at":  {column: "created_at", kind: kindDate},
+	"updated":     {column: "updated_at", kind: kindDate},
+	"updated_at":  {column: "updated_at", kind: kindDate},
+	"tag":         {column: "tag", kind: kindTag},
+	"project":     {column: "project_id", kind: kindRef},
+	"project_id":  {column: "project_id", kind: kindRef},
+	"parent":      {column: "parent_id", kind: kindRef},
+	"parent_id":   {column: "parent_id", kind: kindRef},
+	"archived":    {column: "archived", kind: kindBool},
+}
+
+// Fields mengembalikan nama field yang dikenal (termasuk alias), terurut.
+func Fields() []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Error adalah kesalahan sintaks atau validasi; Pos adalah posisi byte di
+// input (mulai dari 0).
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("filter: %s at position %d", e.Msg, e.Pos)
+}
+
+// Parse mengubah input menjadi AST yang sudah divalidasi. Input kosong
+// menghasilkan nil.
+func Parse(input string) (Expr, error) {
+	p := &parser{input: input}
+	p.skipSpace()
+	if p.eof() {
+		return nil, nil
+	}
+	e, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if !p.eof() {
+		return nil, p.errorf("unexpected %q", string(p.peek()))
+	}
+	return e, nil
+}
+
+// NewComparison membuat perbandingan dari nilai mentah dengan validasi yang
+// sama seperti Parse, mis. untuk parameter query lama seperti due_before.
+func NewComparison(name string, op Op, raw string) (Expr, error) {
+	return comparison(name, op, raw, 0)
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *parser) peek() byte {
+	return p.input[p.pos]
+}
+
+// isSpace hanya mengenal spasi ASCII karena input dibaca per byte; byte
+// UTF-8 lanjutan tidak boleh dianggap spasi.
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func (p *parser) skipSpace() {
+	for !p.eof() && isSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+func (p *parser) errorf(format string, args ...interface{}) *Error {
+	return &Error{Pos: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// keyword melaporkan apakah kata berikutnya adalah kw yang berdiri sendiri.
+func (p *parser) keyword(kw string) bool {
+	rest := p.input[p.pos:]
+	if !strings.HasPrefix(rest, kw) {
+		return false
+	}
+	rest = rest[len(kw):]
+	return rest == "" || isSpace(rest[0]) || rest[0] == '(' || rest[0] == ')'
+}
+
+func (p *parser) parseOr(depth int) (Expr, error) {
+	first, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	exprs := []Expr{first}
+	for p.keyword("OR") {
+		p.pos += len("OR")
+		p.skipSpace()
+		next, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, next)
+	}
+	if len(exprs) == 1 {
+		return first, nil
+	}
+	return Or{Exprs: exprs}, nil
+}
+
+func (p *parser) parseAnd(depth int) (Expr, error) {
+	var exprs []Expr
+	for !p.eof() && p.peek() != ')' && !p.keyword("OR") {
+		if p.keyword("AND") {
+			p.pos += len("AND")
+			p.skipSpace()
+			continue
+		}
+		e, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, e)
+		p.skipSpace()
+	}
+	if len(exprs) == 0 {
+		return nil, p.errorf("expected a term")
+	}
+	return Join(exprs...), nil
+}
+
+func (p *parser) parseUnary(depth int) (Expr, error) {
+	if p.peek() == '-' {
+		if depth >= maxDepth {
+			return nil, p.errorf("too many nested negations")
+		}
+		p.pos++
+		if p.eof() || isSpace(p.peek()) {
+			return nil, p.errorf("expected a term after '-'")
+		}
+		e, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return Not{Expr: e}, nil
+	}
+
+	if p.peek() == '(' {
+		if depth >= maxDepth {
+			return nil, p.errorf("too many nested parentheses")
+		}
+		open := p.pos
+		p.pos++
+		p.skipSpace()
+		e, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if p.eof() || p.peek() != ')' {
+			return nil, &Error{Pos: open, Msg: "unclosed parenthesis"}
+		}
+		p.pos++
+		return e, nil
+	}
+
+	return p.parseTerm()
+}
+
+// parseTerm membaca field<op>nilai atau kata pencarian.
+func (p *parser) parseTerm() (Expr, error) {
+	start := p.pos
+	if p.peek() == '"' {
+		text, err := p.parseQuoted()
+		if err != nil {
+			return nil, err
+		}
+		return Text{Value: text}, nil
+	}
+
+	word := p.parseWord()
+	if word == "" {
+		return nil, p.errorf("unexpected %q", string(p.peek()))
+	}
+	op, ok := p.parseOp()
+	if !ok {
+		return Text{Value: word}, nil
+	}
+
+	valuePos := p.pos
+	var value string
+	switch {
+	case p.eof() || isSpace(p.peek()):
+		return nil, p.errorf("missing value for %s", word)
+	case p.peek() == '"':
+		quoted, err := p.parseQuoted()
+		if err != nil {
+			return nil, err
+		}
+		value = quoted
+	default:
+		value = p.parseWord()
+		if value == "" {
+			return nil, p.errorf("missing value for %s", word)
+		}
+	}
+
+	if _, known := fields[word]; !known {
+		return nil, &Error{Pos: start, Msg: fmt.Sprintf("unknown field %q", word)}
+	}
+	return comparison(word, op, value, valuePos)
+}
+
+// parseWord membaca karakter sampai spasi, kurung, kutip atau operator.
+func (p *parser) parseWord() string {
+	start := p.pos
+	for !p.eof() {
+		c := p.peek()
+		if isSpace(c) || strings.IndexByte(`()":<>`, c) >= 0 {
+			break
+		}
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *parser) parseOp() (Op, bool) {
+	for _, op := range []Op{OpLte, OpGte, OpEq, OpLt, OpGt} {
+		if strings.HasPrefix(p.input[p.pos:], string(op)) {
+			p.pos += len(op)
+			return op, true
+		}
+	}
+	return "", false
+}
+
+// parseQuoted membaca string dalam tanda kutip; \" dan \\ di-escape.
+func (p *parser) parseQuoted() (string, error) {
+	open := p.pos
+	p.pos++
+	var b strings.Builder
+	for !p.eof() {
+		c := p.peek()
+		p.pos++
+		switch c {
+		case '"':
+			return b.String(), nil
+		case '\\':
+			if p.eof() {
+				return "", &Error{Pos: open, Msg: "unterminated string"}
+			}
+			b.WriteByte(p.peek())
+			p.pos++
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return "", &Error{Pos: open, Msg: "unterminated string"}
+}
+
+// comparison memvalidasi nilai sesuai jenis field. Perbandingan tanggal
+// berlaku per hari (UTC): due:2026-11-01 berarti sepanjang hari itu,
+// due<=2026-11-01 termasuk hari itu dan due>2026-11-01 mulai hari berikutnya.
+func comparison(name string, op Op, raw string, pos int) (Expr, error) {
+	f, ok := fields[name]
+	if !ok {
+		return nil, &Error{Pos: pos, Msg: fmt.Sprintf("unknown field %q", name)}
+	}
+	invalid := func(format string, args ...interface{}) error {
+		return &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+	}
+
+	switch f.kind {
+	case kindText:
+		if op != OpEq {
+			return nil, invalid("%s only supports ':'", name)
+		}
+		if raw == "" {
+			return nil, invalid("missing value for %s", name)
+		}
+		return Comparison{Field: f.column, Op: OpEq, Value: raw}, nil
+
+	case kindEnum:
+		if op != OpEq {
+			return nil, invalid("%s only supports ':'", name)
+		}
+		if !slices.Contains(f.values, raw) {
+			return nil, invalid("%s must be one of: %s", name, strings.Join(f.values, " "))
+		}
+		return Comparison{Field: f.column, Op: OpEq, Value: raw}, nil
+
+	case kindTag:
+		if op != OpEq {
+			return nil, invalid("%s only supports ':'", name)
+		}
+		tag := models.NormalizeTagName(raw)
+		if tag == "" {
+			return nil, invalid("missing value for %s", name)
+		}
+		return Comparison{Field: f.column, Op: OpEq, Value: tag}, nil
+
+	case kindBool:
+		if op != OpEq {
+			return nil, invalid("%s only supports ':'", name)
+		}
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, invalid("%s must be true or false", name)
+		}
+		return Comparison{Field: f.column, Op: OpEq, Value: b}, nil
+
+	case kindRef:
+		// 0 sama dengan none agar hasil String bisa di-parse ulang
+		if op != OpEq {
+			return nil, invalid("%s only supports ':'", name)
+		}
+		if raw == "none" {
+			return Comparison{Field: f.column, Op: OpEq, Value: 0}, nil
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			return nil, invalid("%s must be an ID or none", name)
+		}
+		return Comparison{Field: f.column, Op: OpEq, Value: n}, nil
+
+	case kindInt:
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < f.min || n > f.max {
+			return nil, invalid("%s must be an integer between %d and %d", name, f.min, f.max)
+		}
+		return Comparison{Field: f.column, Op: op, Value: n}, nil
+
+	case kindDate:
+		day, err := time.Parse(dateLayout, raw)
+		if err != nil {
+			return nil, invalid("%s must be a date in the format %s", name, dateLayout)
+		}
+		next := day.AddDate(0, 0, 1)
+		switch op {
+		case OpEq:
+			return And{Exprs: []Expr{
+				Comparison{Field: f.column, Op: OpGte, Value: day},
+				Comparison{Field: f.column, Op: OpLt, Value: next},
+			}}, nil
+		case OpLte:
+			return Comparison{Field: f.column, Op: OpLt, Value: next}, nil
+		case OpGt:
+			return Comparison{Field: f.column, Op: OpGte, Value: next}, nil
+		}
+		return Comparison{Field: f.column, Op: op, Value: day}, nil
+	}
+	return nil, invalid("unsupported field %q", name)
+}
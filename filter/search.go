@@ -0,0 +1,181 @@
+// filter/search.go
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+)
+
+// maxSearchTerms membatasi ukuran query full-text yang dikirim ke database.
+const maxSearchTerms = 16
+
+// Search adalah pencarian full-text di title dan description (parameter
+// full_text). Task cocok jika semua term yang tidak Exclude ada dan tidak ada
+// term Exclude. Pencocokan memakai stemming bahasa Inggris di database.
+type Search struct {
+	Terms []SearchTerm
+}
+
+// SearchTerm adalah satu kata, atau frasa jika Words lebih dari satu. Words
+// sudah dinormalisasi: huruf kecil dan hanya huruf/angka.
+type SearchTerm struct {
+	Words   []string
+	Exclude bool
+}
+
+func (Search) expr() {}
+
+func (e Search) String() string {
+	parts := make([]string, len(e.Terms))
+	for i, term := range e.Terms {
+		parts[i] = term.String()
+	}
+	return "search(" + strings.Join(parts, " ") + ")"
+}
+
+func (t SearchTerm) String() string {
+	s := strings.Join(t.Words, " ")
+	if len(t.Words) > 1 {
+		s = `"` + s + `"`
+	}
+	if t.Exclude {
+		s = "-" + s
+	}
+	return s
+}
+
+// ParseSearch mem-parse query pencarian gaya mesin pencari: kata, "frasa"
+// dan -kata untuk mengecualikan. Input kosong menghasilkan nil.
+func ParseSearch(input string) (Expr, error) {
+	var search Search
+	pos := 0
+	for pos < len(input) {
+		if isSpace(input[pos]) {
+			pos++
+			continue
+		}
+
+		start := pos
+		exclude := false
+		if input[pos] == '-' {
+			exclude = true
+			pos++
+		}
+
+		var raw string
+		if pos < len(input) && input[pos] == '"' {
+			end := strings.IndexByte(input[pos+1:], '"')
+			if end < 0 {
+				return nil, &Error{Pos: pos, Msg: "unterminated phrase"}
+			}
+			raw = input[pos+1 : pos+1+end]
+			pos += end + 2
+		} else {
+			end := pos
+			for end < len(input) && !isSpace(input[end]) {
+				end++
+			}
+			raw = input[pos:end]
+			pos = end
+		}
+
+		// Kata dengan tanda baca (mis. e-mail) diperlakukan sebagai frasa
+		words := searchWords(raw)
+		if len(words) == 0 {
+			continue
+		}
+		search.Terms = append(search.Terms, SearchTerm{Words: words, Exclude: exclude})
+		if len(search.Terms) > maxSearchTerms {
+			return nil, &Error{Pos: start, Msg: fmt.Sprintf("at most %d search terms are allowed", maxSearchTerms)}
+		}
+	}
+
+	if len(search.Terms) == 0 {
+		return nil, nil
+	}
+	for _, term := range search.Terms {
+		if !term.Exclude {
+			return search, nil
+		}
+	}
+	return nil, &Error{Pos: 0, Msg: "search needs at least one term that is not excluded"}
+}
+
+// searchWords memecah teks menjadi kata huruf kecil yang hanya berisi huruf
+// dan angka, sehingga aman disusun menjadi query full-text tiap dialek.
+func searchWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// Score adalah relevansi kasar untuk data di memori: jumlah kemunculan term
+// di title (bobot 2) dan description.
+func Score(s Search, task models.Task) float64 {
+	title, description := stemWords(task.Title), stemWords(task.Description)
+	var score float64
+	for _, term := range s.Terms {
+		if term.Exclude {
+			continue
+		}
+		words := stemAll(term.Words)
+		score += 2*float64(countPhrase(title, words)) + float64(countPhrase(description, words))
+	}
+	return score
+}
+
+func matchSearch(s Search, task models.Task) bool {
+	title, description := stemWords(task.Title), stemWords(task.Description)
+	for _, term := range s.Terms {
+		words := stemAll(term.Words)
+		found := countPhrase(title, words) > 0 || countPhrase(description, words) > 0
+		if found == term.Exclude {
+			return false
+		}
+	}
+	return true
+}
+
+func countPhrase(text, phrase []string) int {
+	n := 0
+	for i := 0; i+len(phrase) <= len(text); i++ {
+		match := true
+		for j, word := range phrase {
+			if text[i+j] != word {
+				match = false
+				break
+			}
+		}
+		if match {
+			n++
+		}
+	}
+	return n
+}
+
+func stemWords(s string) []string {
+	return stemAll(searchWords(s))
+}
+
+func stemAll(words []string) []string {
+	stems := make([]string, len(words))
+	for i, word := range words {
+		stems[i] = stem(word)
+	}
+	return stems
+}
+
+// stem adalah pemotong akhiran sederhana untuk pencocokan di memori. Hasilnya
+// mendekati stemmer database untuk bentuk umum (reports, reporting,
+// reported -> report) tetapi tidak identik.
+func stem(word string) string {
+	for _, suffix := range []string{"ing", "ed", "s"} {
+		if strings.HasSuffix(word, suffix) && !strings.HasSuffix(word, "ss") && len(word)-len(suffix) >= 3 {
+			return strings.TrimSuffix(word, suffix)
+		}
+	}
+	return word
+}
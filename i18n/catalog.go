@@ -0,0 +1,194 @@
+// i18n/catalog.go
+package i18n
+
+// catalog adalah pesan bawaan per locale. Key error sama dengan kode stabil
+// di apperrors (mis. "task_not_found"); key "validation.<rule>" untuk pesan
+// per field; key "status.<code>" untuk judul problem.
+var catalog = map[string]map[string]string{
+	"en": {
+		"task.created":       "Task created successfully",
+		"task.updated":       "Task updated successfully",
+		"task.deleted":       "Task deleted successfully",
+		"view.created":       "View created successfully",
+		"view.updated":       "View updated successfully",
+		"view.deleted":       "View deleted successfully",
+		"tag.created":        "Tag created successfully",
+		"tag.renamed":        "Tag renamed successfully",
+		"tag.merged":         "Tags merged successfully",
+		"tag.deleted":        "Tag deleted successfully",
+		"project.created":    "Project created successfully",
+		"project.updated":    "Project updated successfully",
+		"project.deleted":    "Project deleted successfully",
+		"checklist.added":    "Checklist item added successfully",
+		"dependency.created": "Dependency created successfully",
+		"dependency.deleted": "Dependency deleted successfully",
+
+		"task_not_found":               "Task {0} not found",
+		"route_not_found":              "No route matches {0} {1}",
+		"validation_failed":            "Request validation failed",
+		"invalid_field_type":           "Request body has a field of the wrong type",
+		"malformed_body":               "Request body is not valid JSON",
+		"invalid_request":              "Request could not be parsed",
+		"invalid_task_id":              "Task ID must be a positive integer",
+		"invalid_due_date":             "Invalid due_date format",
+		"invalid_credentials":          "Invalid credentials",
+		"authorization_required":       "Authorization header required",
+		"invalid_authorization_header": "Invalid Authorization header format",
+		"invalid_token":                "Invalid or expired token",
+		"rate_limited":                 "Too many requests",
+		"internal_error":               "An unexpected error occurred",
+		"invalid_patch":                "Patch document is not valid",
+		"patch_test_failed":            "JSON Patch test operation failed",
+		"unsupported_patch_type":       "Use {0} or {1}",
+		"task_version_mismatch":        "Task {0} was modified by someone else, fetch it again and retry",
+		"invalid_idempotency_key":      "Idempotency-Key must be at most 255 characters",
+		"idempotency_key_reused":       "Idempotency-Key {0} was already used for a different request",
+		"idempotency_key_in_progress":  "A request with Idempotency-Key {0} is still being processed",
+		"empty_filter":                 "At least one filter criterion is required",
+		"empty_changes":                "Set at least one field to change",
+		"invalid_cursor":               "Cursor is invalid or was issued for a different sort",
+		"invalid_filter":               "Invalid {0}: {1} at position {2}",
+		"view_not_found":               "View {0} not found",
+		"view_not_owner":               "Only the owner can change view {0}",
+		"view_name_taken":              "A view named {0} already exists",
+		"invalid_view_id":              "View ID must be a positive integer or none",
+		"tag_not_found":                "Tag {0} not found",
+		"tag_name_taken":               "A tag named {0} already exists",
+		"task_tag_not_found":           "Task {0} does not have tag {1}",
+		"tag_merge_same":               "A tag cannot be merged into itself",
+		"invalid_tag_id":               "Tag ID must be a positive integer",
+		"project_not_found":            "Project {0} not found",
+		"project_name_taken":           "A project named {0} already exists",
+		"project_not_owner":            "Only the owner can change project {0}",
+		"project_archived":             "Project {0} is archived",
+		"project_not_empty":            "Project {0} still has {1} tasks",
+		"invalid_project_id":           "Project ID must be a positive integer",
+		"subtask_depth_exceeded":       "Subtasks cannot be nested more than {0} levels deep",
+		"subtask_cycle":                "Task {0} cannot be a subtask of itself or its subtasks",
+		"subtask_ancestors_too_deep":   "Task {0} is nested more than {1} levels deep",
+		"checklist_item_not_found":     "Task {0} does not have checklist item {1}",
+		"checklist_full":               "Task {0} already has {1} checklist items",
+		"invalid_checklist_item_id":    "Checklist item ID must be a positive integer",
+		"dependency_exists":            "Task {0} is already blocked by task {1}",
+		"dependency_cycle":             "Task {0} cannot be blocked by task {1} because it would create a cycle",
+		"dependency_not_found":         "Task {0} is not blocked by task {1}",
+		"task_blocked":                 "Task {0} is blocked by unfinished tasks {1}",
+		"fuzzy_not_supported":          "Fuzzy search cannot be combined with OR or NOT on {0}",
+		"fuzzy_too_broad":              "Fuzzy search can rank at most {0} tasks, narrow the filter",
+
+		"validation.required":      "is required",
+		"validation.oneof":         "must be one of: {0}",
+		"validation.datetime":      "must be a date in the format {0}",
+		"validation.min":           "must be at least {0}",
+		"validation.max":           "must be at most {0}",
+		"validation.type":          "must be of type {0}",
+		"validation.excluded_with": "cannot be combined with {0}",
+		"validation.excludesall":   "must not contain any of: {0}",
+		"validation.hexcolor":      "must be a hex color, e.g. #1e90ff",
+		"validation.invalid":       "is invalid",
+
+		"status.400": "Bad Request",
+		"status.401": "Unauthorized",
+		"status.403": "Forbidden",
+		"status.404": "Not Found",
+		"status.409": "Conflict",
+		"status.412": "Precondition Failed",
+		"status.415": "Unsupported Media Type",
+		"status.422": "Unprocessable Content",
+		"status.429": "Too Many Requests",
+		"status.500": "Internal Server Error",
+	},
+	"id": {
+		"task.created":       "Tugas berhasil dibuat",
+		"task.updated":       "Tugas berhasil diperbarui",
+		"task.deleted":       "Tugas berhasil dihapus",
+		"view.created":       "View berhasil dibuat",
+		"view.updated":       "View berhasil diperbarui",
+		"view.deleted":       "View berhasil dihapus",
+		"tag.created":        "Tag berhasil dibuat",
+		"tag.renamed":        "Tag berhasil diganti namanya",
+		"tag.merged":         "Tag berhasil digabung",
+		"tag.deleted":        "Tag berhasil dihapus",
+		"project.created":    "Project berhasil dibuat",
+		"project.updated":    "Project berhasil diperbarui",
+		"project.deleted":    "Project berhasil dihapus",
+		"checklist.added":    "Item checklist berhasil ditambahkan",
+		"dependency.created": "Dependensi berhasil dibuat",
+		"dependency.deleted": "Dependensi berhasil dihapus",
+
+		"task_not_found":               "Tugas {0} tidak ditemukan",
+		"route_not_found":              "Tidak ada rute untuk {0} {1}",
+		"validation_failed":            "Validasi request gagal",
+		"invalid_field_type":           "Ada field dengan tipe yang salah di body request",
+		"malformed_body":               "Body request bukan JSON yang valid",
+		"invalid_request":              "Request tidak dapat dibaca",
+		"invalid_task_id":              "ID tugas harus bilangan bulat positif",
+		"invalid_due_date":             "Format due_date tidak valid",
+		"invalid_credentials":          "Username atau password salah",
+		"authorization_required":       "Header Authorization wajib diisi",
+		"invalid_authorization_header": "Format header Authorization tidak valid",
+		"invalid_token":                "Token tidak valid atau sudah kedaluwarsa",
+		"rate_limited":                 "Terlalu banyak request",
+		"internal_error":               "Terjadi kesalahan yang tidak terduga",
+		"invalid_patch":                "Dokumen patch tidak valid",
+		"patch_test_failed":            "Operasi test pada JSON Patch gagal",
+		"unsupported_patch_type":       "Gunakan {0} atau {1}",
+		"task_version_mismatch":        "Tugas {0} sudah diubah orang lain, ambil ulang lalu coba lagi",
+		"invalid_idempotency_key":      "Idempotency-Key maksimal 255 karakter",
+		"idempotency_key_reused":       "Idempotency-Key {0} sudah dipakai untuk request lain",
+		"idempotency_key_in_progress":  "Request dengan Idempotency-Key {0} masih diproses",
+		"empty_filter":                 "Minimal satu kriteria filter wajib diisi",
+		"empty_changes":                "Isi minimal satu field yang akan diubah",
+		"invalid_cursor":               "Cursor tidak valid atau dibuat untuk urutan lain",
+		"invalid_filter":               "{0} tidak valid: {1} di posisi {2}",
+		"view_not_found":               "View {0} tidak ditemukan",
+		"view_not_owner":               "Hanya pemilik yang boleh mengubah view {0}",
+		"view_name_taken":              "View bernama {0} sudah ada",
+		"invalid_view_id":              "ID view harus bilangan bulat positif atau none",
+		"tag_not_found":                "Tag {0} tidak ditemukan",
+		"tag_name_taken":               "Tag bernama {0} sudah ada",
+		"task_tag_not_found":           "Tugas {0} tidak memiliki tag {1}",
+		"tag_merge_same":               "Tag tidak bisa digabung ke dirinya sendiri",
+		"invalid_tag_id":               "ID tag harus bilangan bulat positif",
+		"project_not_found":            "Project {0} tidak ditemukan",
+		"project_name_taken":           "Project bernama {0} sudah ada",
+		"project_not_owner":            "Hanya pemilik yang boleh mengubah project {0}",
+		"project_archived":             "Project {0} sudah diarsipkan",
+		"project_not_empty":            "Project {0} masih memiliki {1} tugas",
+		"invalid_project_id":           "ID project harus bilangan bulat positif",
+		"subtask_depth_exceeded":       "Subtask tidak boleh bertingkat lebih dari {0} level",
+		"subtask_cycle":                "Tugas {0} tidak boleh menjadi subtask dari dirinya sendiri atau subtask-nya",
+		"subtask_ancestors_too_deep":   "Tugas {0} bertingkat lebih dari {1} level",
+		"checklist_item_not_found":     "Tugas {0} tidak memiliki item checklist {1}",
+		"checklist_full":               "Tugas {0} sudah memiliki {1} item checklist",
+		"invalid_checklist_item_id":    "ID item checklist harus bilangan bulat positif",
+		"dependency_exists":            "Tugas {0} sudah diblokir oleh tugas {1}",
+		"dependency_cycle":             "Tugas {0} tidak boleh diblokir oleh tugas {1} karena akan membentuk siklus",
+		"dependency_not_found":         "Tugas {0} tidak diblokir oleh tugas {1}",
+		"task_blocked":                 "Tugas {0} masih diblokir oleh tugas yang belum selesai: {1}",
+		"fuzzy_not_supported":          "Pencarian fuzzy tidak bisa digabung dengan OR atau NOT di {0}",
+		"fuzzy_too_broad":              "Pencarian fuzzy hanya bisa menilai maksimal {0} tugas, persempit filter",
+
+		"validation.required":      "wajib diisi",
+		"validation.oneof":         "harus salah satu dari: {0}",
+		"validation.datetime":      "harus berupa tanggal dengan format {0}",
+		"validation.min":           "minimal {0}",
+		"validation.max":           "maksimal {0}",
+		"validation.type":          "harus bertipe {0}",
+		"validation.excluded_with": "tidak bisa digabung dengan {0}",
+		"validation.excludesall":   "tidak boleh mengandung: {0}",
+		"validation.hexcolor":      "harus berupa warna hex, mis. #1e90ff",
+		"validation.invalid":       "tidak valid",
+
+		"status.400": "Permintaan Tidak Valid",
+		"status.401": "Tidak Terautentikasi",
+		"status.403": "Akses Ditolak",
+		"status.404": "Tidak Ditemukan",
+		"status.409": "Konflik",
+		"status.412": "Prasyarat Gagal",
+		"status.415": "Tipe Media Tidak Didukung",
+		"status.422": "Konten Tidak Dapat Diproses",
+		"status.429": "Terlalu Banyak Permintaan",
+		"status.500": "Kesalahan Server",
+	},
+}
@@ -0,0 +1,88 @@
+// i18n/i18n.go
+package i18n
+
+import (
+	"fmt"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale dipakai jika client tidak meminta bahasa yang didukung.
+const DefaultLocale = "en"
+
+// ContextKey adalah key gin.Context tempat locale request disimpan.
+const ContextKey = "locale"
+
+var uni = ut.New(en.New(), en.New(), id.New())
+
+func init() {
+	for locale, messages := range catalog {
+		for key, text := range messages {
+			if err := Register(locale, key, text); err != nil {
+				panic(err)
+			}
+		}
+	}
+}
+
+// Register menambah atau mengganti pesan key untuk locale. Parameter ditulis
+// {0}, {1}, dst. Dipakai untuk memperluas katalog bawaan.
+func Register(locale, key, text string) error {
+	trans, ok := uni.GetTranslator(locale)
+	if !ok {
+		return fmt.Errorf("i18n: unsupported locale %q", locale)
+	}
+	return trans.Add(key, text, true)
+}
+
+// Supported melaporkan apakah locale punya katalog.
+func Supported(locale string) bool {
+	_, ok := uni.GetTranslator(locale)
+	return ok
+}
+
+// Negotiate memilih locale dari preferensi user (jika ada dan didukung)
+// lalu header Accept-Language, dengan DefaultLocale sebagai cadangan.
+func Negotiate(preference, acceptLanguage string) string {
+	if preference != "" && Supported(preference) {
+		return preference
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil {
+		return DefaultLocale
+	}
+	for _, tag := range tags {
+		base, _ := tag.Base()
+		if Supported(base.String()) {
+			return base.String()
+		}
+	}
+	return DefaultLocale
+}
+
+// T menerjemahkan key ke locale. Jika key tidak ada di locale tersebut,
+// dicoba DefaultLocale; jika tetap tidak ada, ok bernilai false.
+func T(locale, key string, params ...string) (text string, ok bool) {
+	for _, l := range []string{locale, DefaultLocale} {
+		trans, found := uni.GetTranslator(l)
+		if !found {
+			continue
+		}
+		if text, err := trans.T(key, params...); err == nil {
+			return text, true
+		}
+	}
+	return "", false
+}
+
+// Message seperti T tetapi mengembalikan fallback jika key tidak ditemukan.
+func Message(locale, key, fallback string, params ...string) string {
+	if text, ok := T(locale, key, params...); ok {
+		return text
+	}
+	return fallback
+}
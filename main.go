@@ -2,27 +2,90 @@ package main
 
 func main() {
 	// todo-api/
+	// ├── apperrors/
+	// │   └── apperrors.go
 	// ├── cmd/
 	// │   └── main.go
+	// │   └── commands.go
 	// ├── config/
 	// │   └── config.go
+	// │   └── loader.go
+	// │   └── print.go
+	// │   └── secrets.go
+	// │   └── validate.go
+	// │   └── watcher.go
 	// ├── controllers/
 	// │   └── task_controller.go
+	// │   └── task_bulk_controller.go
+	// │   └── task_pagination.go
+	// │   └── task_subtask_controller.go
 	// |   └── auth_controller.go
+	// |   └── health_controller.go
+	// |   └── view_controller.go
+	// |   └── tag_controller.go
+	// |   └── project_controller.go
+	// |   └── checklist_controller.go
+	// |   └── dependency_controller.go
+	// ├── filter/
+	// │   └── ast.go
+	// │   └── parse.go
+	// │   └── match.go
+	// │   └── search.go
+	// │   └── fuzzy.go
+	// ├── migrations/
+	// │   └── migrations.go
 	// ├── models/
 	// │   └── task.go
+	// │   └── view.go
+	// │   └── tag.go
+	// │   └── project.go
+	// │   └── checklist.go
+	// │   └── dependency.go
 	// ├── repositories/
 	// │   └── task_repository.go
 	// |   └── redis.go
+	// |   └── dialect.go
+	// |   └── bulk.go
+	// |   └── bulk_filter.go
+	// |   └── unit_of_work.go
+	// |   └── idempotency_store.go
+	// |   └── sort.go
+	// |   └── filter_sql.go
+	// |   └── fulltext.go
+	// |   └── fuzzy.go
+	// |   └── in_memory.go
+	// |   └── view_repository.go
+	// |   └── tag_repository.go
+	// |   └── project_repository.go
+	// |   └── subtask.go
+	// |   └── checklist_repository.go
+	// |   └── dependency_repository.go
 	// ├── services/
 	// │   └── task_service.go
 	// |   └── mock_service.go
+	// |   └── view_service.go
+	// |   └── mock_view_service.go
+	// |   └── tag_service.go
+	// |   └── project_service.go
+	// |   └── subtask.go
+	// |   └── checklist_service.go
+	// |   └── dependency_service.go
+	// ├── i18n/
+	// │   └── i18n.go
+	// │   └── catalog.go
 	// ├── middlewares/
 	// │   └── auth.go
+	// │   └── errors.go
+	// │   └── idempotency.go
+	// │   └── locale.go
 	// │   └── logger.go
+	// │   └── rate_limit.go
 	// ├── utils/
 	// │   └── jwt.go
+	// │   └── cursor.go
 	// │   └── validator.go
+	// ├── tracing/
+	// │   └── tracing.go
 	// ├── tests/
 	// │   └── task_test.go
 	// ├── go.mod
@@ -2,10 +2,11 @@
 package middlewares
 
 import (
-	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/i18n"
 	"github.com/programmercintasunnah/go-todolist-ilcs/utils"
 )
 
@@ -13,14 +14,14 @@ func JWTAuth(secret string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+			c.Error(apperrors.Unauthorized("authorization_required", "Authorization header required"))
 			c.Abort()
 			return
 		}
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid Authorization header format"})
+			c.Error(apperrors.Unauthorized("invalid_authorization_header", "Invalid Authorization header format"))
 			c.Abort()
 			return
 		}
@@ -28,13 +29,19 @@ func JWTAuth(secret string) gin.HandlerFunc {
 		tokenString := parts[1]
 		claims, err := utils.ParseJWT(tokenString, secret)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			c.Error(apperrors.Unauthorized("invalid_token", "Invalid or expired token"))
 			c.Abort()
 			return
 		}
 
 		// You can set user information to context if needed
 		c.Set("username", claims.Username)
+
+		// Preferensi bahasa user mengalahkan Accept-Language
+		if claims.Locale != "" && i18n.Supported(claims.Locale) {
+			c.Set(i18n.ContextKey, claims.Locale)
+			c.Header("Content-Language", claims.Locale)
+		}
 		c.Next()
 	}
 }
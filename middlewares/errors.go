@@ -0,0 +1,110 @@
+// middlewares/errors.go
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/i18n"
+	"github.com/sirupsen/logrus"
+)
+
+const problemContentType = "application/problem+json"
+
+// Problem adalah body error sesuai RFC 7807. Code adalah extension member
+// yang stabil untuk dibaca mesin; Title dan Detail boleh berubah.
+type Problem struct {
+	Type     string                 `json:"type"`
+	Title    string                 `json:"title"`
+	Status   int                    `json:"status"`
+	Detail   string                 `json:"detail,omitempty"`
+	Instance string                 `json:"instance,omitempty"`
+	Code     string                 `json:"code"`
+	Errors   []apperrors.FieldError `json:"errors,omitempty"`
+}
+
+// ErrorHandler mengubah error terakhir yang dicatat handler lewat c.Error
+// menjadi respons application/problem+json. Error yang bukan error domain
+// dianggap 500 dan detailnya tidak dikirim ke client.
+func ErrorHandler(logger *logrus.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		problem := NewProblem(err, c.GetString(i18n.ContextKey))
+		problem.Instance = c.Request.URL.Path
+
+		if problem.Status >= http.StatusInternalServerError {
+			logger.WithError(err).WithField("path", c.Request.URL.Path).Error("Unhandled error")
+		}
+
+		WriteProblem(c, problem)
+	}
+}
+
+// NewProblem memetakan err ke Problem tanpa Instance, dengan title, detail
+// dan pesan per field diterjemahkan ke locale.
+func NewProblem(err error, locale string) Problem {
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) {
+		appErr = &apperrors.Error{Code: "internal_error", Message: "An unexpected error occurred"}
+	}
+
+	status := statusFor(appErr)
+	fields := make([]apperrors.FieldError, len(appErr.Fields))
+	for i, fe := range appErr.Fields {
+		fe.Message = i18n.Message(locale, "validation."+fe.Rule, fe.Message, fe.Param)
+		fields[i] = fe
+	}
+
+	return Problem{
+		Type:   problemType(appErr.Code),
+		Title:  i18n.Message(locale, "status."+strconv.Itoa(status), http.StatusText(status)),
+		Status: status,
+		Detail: i18n.Message(locale, appErr.Code, appErr.Message, appErr.Params...),
+		Code:   appErr.Code,
+		Errors: fields,
+	}
+}
+
+// WriteProblem menulis problem dan menghentikan handler berikutnya.
+func WriteProblem(c *gin.Context, problem Problem) {
+	// Content-Type diset lebih dulu supaya tidak ditimpa application/json oleh c.JSON
+	c.Header("Content-Type", problemContentType)
+	c.AbortWithStatusJSON(problem.Status, problem)
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, apperrors.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, apperrors.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, apperrors.ErrValidation):
+		return http.StatusBadRequest
+	case errors.Is(err, apperrors.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, apperrors.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, apperrors.ErrRateLimited):
+		return http.StatusTooManyRequests
+	case errors.Is(err, apperrors.ErrUnsupportedMediaType):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, apperrors.ErrPreconditionFailed):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, apperrors.ErrUnprocessable):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusInternalServerError
+}
+
+func problemType(code string) string {
+	return "/problems/" + code
+}
@@ -0,0 +1,161 @@
+// middlewares/idempotency.go
+package middlewares
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/config"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/programmercintasunnah/go-todolist-ilcs/utils"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	maxIdempotencyKeyLen = 255
+
+	// idempotencyLockTTL membatasi berapa lama key tertahan "sedang diproses"
+	// jika proses mati sebelum respons tersimpan.
+	idempotencyLockTTL = time.Minute
+)
+
+// replayedHeaders adalah header respons yang ikut disimpan dan diputar ulang.
+var replayedHeaders = []string{"Content-Type", "Location", "ETag"}
+
+// Idempotency membuat POST, PUT, PATCH dan DELETE yang membawa header
+// Idempotency-Key aman diulang. Respons sukses disimpan per user dan key
+// selama idempotency.ttl; retry dengan body yang sama mendapat respons asli
+// (dengan header Idempotent-Replayed), sedangkan key yang dipakai ulang untuk
+// request lain ditolak 422. Respons error tidak disimpan sehingga client
+// bisa memperbaiki request lalu mencoba lagi dengan key yang sama.
+//
+// Jika store tidak bisa dihubungi request tetap diproses tanpa jaminan
+// idempotensi, sama seperti cache task yang gagal.
+func Idempotency(store repositories.IdempotencyStore, watcher *config.Watcher, logger *logrus.Logger) gin.HandlerFunc {
+	var ttl atomic.Int64
+	watcher.Subscribe(func(cfg config.Config) {
+		ttl.Store(int64(cfg.Idempotency.TTL))
+	})
+
+	return func(c *gin.Context) {
+		key := c.GetHeader(idempotencyKeyHeader)
+		if key == "" || !isMutating(c.Request.Method) {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLen {
+			c.Error(apperrors.Validation("invalid_idempotency_key", "Idempotency-Key must be at most 255 characters", apperrors.FieldError{
+				Field:   idempotencyKeyHeader,
+				Rule:    "max",
+				Param:   "255",
+				Message: utils.FieldMessage("max", "255"),
+			}))
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.Error(apperrors.Validation("invalid_request", "Request could not be parsed").Wrap(err))
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		storeKey := "idempotency:" + c.GetString("username") + ":" + key
+		fingerprint := requestFingerprint(c.Request, body)
+
+		existing, reserved, err := store.Reserve(ctx, storeKey, fingerprint, idempotencyLockTTL)
+		if err != nil {
+			logger.WithError(err).Warn("Idempotency store unavailable, processing request without it")
+			c.Next()
+			return
+		}
+		if !reserved {
+			switch {
+			case existing.Fingerprint != fingerprint:
+				c.Error(apperrors.Unprocessable("idempotency_key_reused", "Idempotency-Key was already used for a different request").WithParams(key))
+			case !existing.Completed:
+				c.Error(apperrors.Conflict("idempotency_key_in_progress", "A request with this Idempotency-Key is still being processed").WithParams(key))
+			default:
+				for name, values := range existing.Header {
+					c.Writer.Header()[name] = values
+				}
+				c.Header("Idempotent-Replayed", "true")
+				c.Data(existing.Status, existing.Header.Get("Content-Type"), existing.Body)
+			}
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		status := c.Writer.Status()
+		if len(c.Errors) > 0 || status >= http.StatusInternalServerError {
+			if err := store.Release(ctx, storeKey); err != nil {
+				logger.WithError(err).Warn("Failed to release idempotency key")
+			}
+			return
+		}
+
+		header := make(http.Header)
+		for _, name := range replayedHeaders {
+			if value := c.Writer.Header().Get(name); value != "" {
+				header.Set(name, value)
+			}
+		}
+		record := repositories.IdempotencyRecord{
+			Fingerprint: fingerprint,
+			Status:      status,
+			Header:      header,
+			Body:        recorder.body.Bytes(),
+		}
+		if err := store.Complete(ctx, storeKey, record, time.Duration(ttl.Load())); err != nil {
+			logger.WithError(err).Warn("Failed to store idempotent response")
+		}
+	}
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// requestFingerprint membedakan request yang memakai key yang sama:
+// method, path dan body harus identik agar respons boleh diputar ulang.
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.RequestURI()+"\n")
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder menyalin body respons agar bisa disimpan setelah handler selesai.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
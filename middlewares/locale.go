@@ -0,0 +1,19 @@
+// middlewares/locale.go
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/programmercintasunnah/go-todolist-ilcs/i18n"
+)
+
+// Locale memilih bahasa respons dari header Accept-Language. JWTAuth dapat
+// menimpanya dengan preferensi yang tersimpan di token user.
+func Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := i18n.Negotiate("", c.GetHeader("Accept-Language"))
+		c.Set(i18n.ContextKey, locale)
+		c.Header("Content-Language", locale)
+		c.Header("Vary", "Accept-Language")
+		c.Next()
+	}
+}
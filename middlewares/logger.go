@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func Logger(logger *logrus.Logger) gin.HandlerFunc {
@@ -22,7 +23,7 @@ func Logger(logger *logrus.Logger) gin.HandlerFunc {
 		status := c.Writer.Status()
 
 		// Log format
-		logger.WithFields(logrus.Fields{
+		fields := logrus.Fields{
 			"status":        status,
 			"method":        c.Request.Method,
 			"path":          c.Request.URL.Path,
@@ -30,6 +31,13 @@ func Logger(logger *logrus.Logger) gin.HandlerFunc {
 			"latency":       latency,
 			"user_agent":    c.Request.UserAgent(),
 			"error_message": c.Errors.ByType(gin.ErrorTypePrivate).String(),
-		}).Info("Request completed")
+		}
+
+		// Sertakan trace_id agar log bisa dikorelasikan dengan trace
+		if sc := trace.SpanContextFromContext(c.Request.Context()); sc.HasTraceID() {
+			fields["trace_id"] = sc.TraceID().String()
+		}
+
+		logger.WithFields(fields).Info("Request completed")
 	}
 }
@@ -0,0 +1,90 @@
+// middlewares/rate_limit.go
+package middlewares
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/config"
+	"golang.org/x/time/rate"
+)
+
+// clientIdleTTL adalah lama limiter client tanpa request sebelum dibuang.
+const clientIdleTTL = 10 * time.Minute
+
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// rateLimiter membatasi request per client (username jika sudah login,
+// selain itu IP) dengan token bucket. Batasnya mengikuti reload konfigurasi.
+type rateLimiter struct {
+	mu       sync.Mutex
+	limit    rate.Limit
+	burst    int
+	clients  map[string]*clientLimiter
+	lastScan time.Time
+}
+
+func RateLimit(watcher *config.Watcher) gin.HandlerFunc {
+	rl := &rateLimiter{clients: make(map[string]*clientLimiter)}
+	watcher.Subscribe(rl.apply)
+
+	return func(c *gin.Context) {
+		key := c.ClientIP()
+		if username := c.GetString("username"); username != "" {
+			key = "user:" + username
+		}
+
+		if !rl.allow(key) {
+			c.Header("Retry-After", "1")
+			c.Error(apperrors.RateLimited("rate_limited", "Too many requests"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+func (rl *rateLimiter) apply(cfg config.Config) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.limit = rate.Limit(cfg.RateLimit.RequestsPerSecond)
+	rl.burst = cfg.RateLimit.Burst
+	for _, client := range rl.clients {
+		client.limiter.SetLimit(rl.limit)
+		client.limiter.SetBurst(rl.burst)
+	}
+}
+
+func (rl *rateLimiter) allow(key string) bool {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	// Limit 0 berarti rate limit dimatikan
+	if rl.limit == 0 {
+		return true
+	}
+
+	now := time.Now()
+	if now.Sub(rl.lastScan) > clientIdleTTL {
+		for k, client := range rl.clients {
+			if now.Sub(client.lastSeen) > clientIdleTTL {
+				delete(rl.clients, k)
+			}
+		}
+		rl.lastScan = now
+	}
+
+	client, ok := rl.clients[key]
+	if !ok {
+		client = &clientLimiter{limiter: rate.NewLimiter(rl.limit, rl.burst)}
+		rl.clients[key] = client
+	}
+	client.lastSeen = now
+	return client.limiter.AllowN(now, 1)
+}
@@ -0,0 +1,487 @@
+// migrations/migrations.go
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Migration adalah satu langkah perubahan skema. Setiap dialek punya
+// SQL sendiri karena Postgres, Oracle dan SQLite berbeda untuk tipe data dan DDL.
+type Migration struct {
+	Version  int
+	Name     string
+	Postgres []string
+	Oracle   []string
+	SQLite   []string
+}
+
+// oracleIgnoreExists membungkus DDL Oracle agar ORA-00955 (objek sudah ada)
+// dan ORA-01430 (kolom sudah ada) diabaikan, untuk database yang tabelnya
+// dibuat manual sebelum ada migrasi atau migrasi yang gagal di tengah jalan.
+func oracleIgnoreExists(ddl string) string {
+	return "BEGIN EXECUTE IMMEDIATE '" + ddl + "'; EXCEPTION WHEN OTHERS THEN IF SQLCODE NOT IN (-955, -1430) THEN RAISE; END IF; END;"
+}
+
+// oracleTextPreference membuat preference Oracle Text beserta atributnya
+// (pasangan nama, nilai) jika belum ada, agar migrasi yang gagal di tengah
+// jalan bisa dijalankan ulang.
+func oracleTextPreference(name, object string, attributes ...string) string {
+	var b strings.Builder
+	b.WriteString("DECLARE n NUMBER; BEGIN SELECT COUNT(*) INTO n FROM ctx_user_preferences WHERE pre_name = UPPER('" + name + "'); ")
+	b.WriteString("IF n = 0 THEN ctx_ddl.create_preference('" + name + "', '" + object + "'); ")
+	for i := 0; i+1 < len(attributes); i += 2 {
+		b.WriteString("ctx_ddl.set_attribute('" + name + "', '" + attributes[i] + "', '" + attributes[i+1] + "'); ")
+	}
+	b.WriteString("END IF; END;")
+	return b.String()
+}
+
+var all = []Migration{
+	{
+		Version: 1,
+		Name:    "create_tasks",
+		Postgres: []string{
+			`CREATE TABLE IF NOT EXISTS tasks (
+				id SERIAL PRIMARY KEY,
+				title VARCHAR(255) NOT NULL,
+				description TEXT,
+				status VARCHAR(20) CHECK (status IN ('pending', 'completed')) NOT NULL,
+				due_date TIMESTAMP NOT NULL,
+				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				deleted_at TIMESTAMP
+			)`,
+		},
+		Oracle: []string{
+			oracleIgnoreExists(`CREATE TABLE tasks (
+				id NUMBER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+				title VARCHAR2(255) NOT NULL,
+				description VARCHAR2(4000),
+				status VARCHAR2(20) CHECK (status IN (''pending'', ''completed'')) NOT NULL,
+				due_date TIMESTAMP NOT NULL,
+				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				deleted_at TIMESTAMP
+			)`),
+		},
+		SQLite: []string{
+			`CREATE TABLE IF NOT EXISTS tasks (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				title VARCHAR(255) NOT NULL,
+				description TEXT,
+				status VARCHAR(20) CHECK (status IN ('pending', 'completed')) NOT NULL,
+				due_date TIMESTAMP NOT NULL,
+				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				deleted_at TIMESTAMP
+			)`,
+		},
+	},
+	{
+		Version: 2,
+		Name:    "add_tasks_version",
+		Postgres: []string{
+			`ALTER TABLE tasks ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1`,
+		},
+		Oracle: []string{
+			oracleIgnoreExists(`ALTER TABLE tasks ADD (version NUMBER(10) DEFAULT 1 NOT NULL)`),
+		},
+		SQLite: []string{
+			`ALTER TABLE tasks ADD COLUMN version INTEGER NOT NULL DEFAULT 1`,
+		},
+	},
+	{
+		Version: 3,
+		Name:    "add_tasks_priority",
+		Postgres: []string{
+			`ALTER TABLE tasks ADD COLUMN IF NOT EXISTS priority SMALLINT NOT NULL DEFAULT 0`,
+		},
+		Oracle: []string{
+			oracleIgnoreExists(`ALTER TABLE tasks ADD (priority NUMBER(1) DEFAULT 0 NOT NULL)`),
+		},
+		SQLite: []string{
+			`ALTER TABLE tasks ADD COLUMN priority INTEGER NOT NULL DEFAULT 0`,
+		},
+	},
+	{
+		// Indeks full-text untuk parameter full_text. Oracle memakai
+		// MULTI_COLUMN_DATASTORE yang diindeks pada kolom title, sehingga
+		// perubahan description baru terindeks jika title ikut di-UPDATE;
+		// trigger tasks_search_reindex menandai title untuk setiap UPDATE
+		// description.
+		Version: 4,
+		Name:    "add_tasks_full_text",
+		Postgres: []string{
+			`ALTER TABLE tasks ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+				setweight(to_tsvector('english', COALESCE(title, '')), 'A') ||
+				setweight(to_tsvector('english', COALESCE(description, '')), 'B')
+			) STORED`,
+			`CREATE INDEX IF NOT EXISTS tasks_search_idx ON tasks USING GIN (search_vector)`,
+		},
+		Oracle: []string{
+			oracleTextPreference("tasks_search_ds", "MULTI_COLUMN_DATASTORE", "COLUMNS", "title, description", "DELIMITER", "NEWLINE"),
+			oracleTextPreference("tasks_search_lexer", "BASIC_LEXER", "INDEX_STEMS", "ENGLISH"),
+			oracleIgnoreExists(`CREATE INDEX tasks_search_idx ON tasks (title) INDEXTYPE IS CTXSYS.CONTEXT
+				PARAMETERS (''DATASTORE tasks_search_ds LEXER tasks_search_lexer SYNC (ON COMMIT)'')`),
+			`CREATE OR REPLACE TRIGGER tasks_search_reindex BEFORE UPDATE OF description ON tasks FOR EACH ROW
+			BEGIN
+				:new.title := :new.title;
+			END;`,
+		},
+		SQLite: []string{
+			`CREATE VIRTUAL TABLE IF NOT EXISTS tasks_fts USING fts5(
+				title, description, content='tasks', content_rowid='id', tokenize='porter unicode61'
+			)`,
+			`CREATE TRIGGER IF NOT EXISTS tasks_fts_insert AFTER INSERT ON tasks BEGIN
+				INSERT INTO tasks_fts (rowid, title, description) VALUES (new.id, new.title, new.description);
+			END`,
+			`CREATE TRIGGER IF NOT EXISTS tasks_fts_delete AFTER DELETE ON tasks BEGIN
+				INSERT INTO tasks_fts (tasks_fts, rowid, title, description) VALUES ('delete', old.id, old.title, old.description);
+			END`,
+			`CREATE TRIGGER IF NOT EXISTS tasks_fts_update AFTER UPDATE OF title, description ON tasks BEGIN
+				INSERT INTO tasks_fts (tasks_fts, rowid, title, description) VALUES ('delete', old.id, old.title, old.description);
+				INSERT INTO tasks_fts (rowid, title, description) VALUES (new.id, new.title, new.description);
+			END`,
+			`INSERT INTO tasks_fts (tasks_fts) VALUES ('rebuild')`,
+		},
+	},
+	{
+		// Pencarian fuzzy memakai pg_trgm; indeks trigram juga dipakai
+		// pencarian substring ILIKE. Oracle dan SQLite menilai kemiripan di
+		// proses sehingga tidak perlu perubahan skema.
+		Version: 5,
+		Name:    "add_tasks_title_trigram",
+		Postgres: []string{
+			`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+			`CREATE INDEX IF NOT EXISTS tasks_title_trgm_idx ON tasks USING GIN (title gin_trgm_ops)`,
+		},
+	},
+	{
+		// Kolom teks view boleh NULL karena Oracle menyimpan string kosong
+		// sebagai NULL.
+		Version: 6,
+		Name:    "create_views",
+		Postgres: []string{
+			`CREATE TABLE IF NOT EXISTS views (
+				id SERIAL PRIMARY KEY,
+				owner VARCHAR(100) NOT NULL,
+				name VARCHAR(100) NOT NULL,
+				filter_query TEXT,
+				search_query VARCHAR(255),
+				sort_order VARCHAR(255),
+				page_size INTEGER NOT NULL DEFAULT 0,
+				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				UNIQUE (owner, name)
+			)`,
+			`CREATE TABLE IF NOT EXISTS view_shares (
+				view_id INTEGER NOT NULL REFERENCES views (id) ON DELETE CASCADE,
+				username VARCHAR(100) NOT NULL,
+				PRIMARY KEY (view_id, username)
+			)`,
+			`CREATE INDEX IF NOT EXISTS view_shares_username_idx ON view_shares (username)`,
+			`CREATE TABLE IF NOT EXISTS view_defaults (
+				username VARCHAR(100) PRIMARY KEY,
+				view_id INTEGER NOT NULL REFERENCES views (id) ON DELETE CASCADE
+			)`,
+		},
+		Oracle: []string{
+			oracleIgnoreExists(`CREATE TABLE views (
+				id NUMBER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+				owner VARCHAR2(100) NOT NULL,
+				name VARCHAR2(100) NOT NULL,
+				filter_query VARCHAR2(4000),
+				search_query VARCHAR2(255),
+				sort_order VARCHAR2(255),
+				page_size NUMBER(3) DEFAULT 0 NOT NULL,
+				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				CONSTRAINT views_owner_name_uk UNIQUE (owner, name)
+			)`),
+			oracleIgnoreExists(`CREATE TABLE view_shares (
+				view_id NUMBER NOT NULL REFERENCES views (id) ON DELETE CASCADE,
+				username VARCHAR2(100) NOT NULL,
+				PRIMARY KEY (view_id, username)
+			)`),
+			oracleIgnoreExists(`CREATE INDEX view_shares_username_idx ON view_shares (username)`),
+			oracleIgnoreExists(`CREATE TABLE view_defaults (
+				username VARCHAR2(100) PRIMARY KEY,
+				view_id NUMBER NOT NULL REFERENCES views (id) ON DELETE CASCADE
+			)`),
+		},
+		SQLite: []string{
+			`CREATE TABLE IF NOT EXISTS views (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				owner VARCHAR(100) NOT NULL,
+				name VARCHAR(100) NOT NULL,
+				filter_query TEXT,
+				search_query VARCHAR(255),
+				sort_order VARCHAR(255),
+				page_size INTEGER NOT NULL DEFAULT 0,
+				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				UNIQUE (owner, name)
+			)`,
+			`CREATE TABLE IF NOT EXISTS view_shares (
+				view_id INTEGER NOT NULL REFERENCES views (id) ON DELETE CASCADE,
+				username VARCHAR(100) NOT NULL,
+				PRIMARY KEY (view_id, username)
+			)`,
+			`CREATE INDEX IF NOT EXISTS view_shares_username_idx ON view_shares (username)`,
+			`CREATE TABLE IF NOT EXISTS view_defaults (
+				username VARCHAR(100) PRIMARY KEY,
+				view_id INTEGER NOT NULL REFERENCES views (id) ON DELETE CASCADE
+			)`,
+		},
+	},
+	{
+		// Indeks task_tags (tag_id) dipakai filter tag dan operasi per tag;
+		// primary key sudah melayani pencarian per task.
+		Version: 7,
+		Name:    "create_tags",
+		Postgres: []string{
+			`CREATE TABLE IF NOT EXISTS tags (
+				id SERIAL PRIMARY KEY,
+				name VARCHAR(50) NOT NULL UNIQUE,
+				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+			)`,
+			`CREATE TABLE IF NOT EXISTS task_tags (
+				task_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+				tag_id INTEGER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+				PRIMARY KEY (task_id, tag_id)
+			)`,
+			`CREATE INDEX IF NOT EXISTS task_tags_tag_id_idx ON task_tags (tag_id)`,
+		},
+		Oracle: []string{
+			oracleIgnoreExists(`CREATE TABLE tags (
+				id NUMBER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+				name VARCHAR2(50) NOT NULL,
+				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				CONSTRAINT tags_name_uk UNIQUE (name)
+			)`),
+			oracleIgnoreExists(`CREATE TABLE task_tags (
+				task_id NUMBER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+				tag_id NUMBER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+				PRIMARY KEY (task_id, tag_id)
+			)`),
+			oracleIgnoreExists(`CREATE INDEX task_tags_tag_id_idx ON task_tags (tag_id)`),
+		},
+		SQLite: []string{
+			`CREATE TABLE IF NOT EXISTS tags (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				name VARCHAR(50) NOT NULL UNIQUE,
+				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+			)`,
+			`CREATE TABLE IF NOT EXISTS task_tags (
+				task_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+				tag_id INTEGER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+				PRIMARY KEY (task_id, tag_id)
+			)`,
+			`CREATE INDEX IF NOT EXISTS task_tags_tag_id_idx ON task_tags (tag_id)`,
+		},
+	},
+	{
+		// Flag archived disimpan sebagai 0/1 di semua dialek karena Oracle
+		// tidak punya tipe boolean. tasks.archived menyalin flag project agar
+		// daftar task bisa menyaringnya tanpa JOIN.
+		Version: 8,
+		Name:    "create_projects",
+		Postgres: []string{
+			`CREATE TABLE IF NOT EXISTS projects (
+				id SERIAL PRIMARY KEY,
+				owner VARCHAR(100) NOT NULL,
+				name VARCHAR(100) NOT NULL,
+				color VARCHAR(7),
+				archived SMALLINT NOT NULL DEFAULT 0,
+				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				UNIQUE (owner, name)
+			)`,
+			`ALTER TABLE tasks ADD COLUMN IF NOT EXISTS project_id INTEGER REFERENCES projects (id)`,
+			`ALTER TABLE tasks ADD COLUMN IF NOT EXISTS archived SMALLINT NOT NULL DEFAULT 0`,
+			`CREATE INDEX IF NOT EXISTS tasks_project_id_idx ON tasks (project_id)`,
+		},
+		Oracle: []string{
+			oracleIgnoreExists(`CREATE TABLE projects (
+				id NUMBER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+				owner VARCHAR2(100) NOT NULL,
+				name VARCHAR2(100) NOT NULL,
+				color VARCHAR2(7),
+				archived NUMBER(1) DEFAULT 0 NOT NULL,
+				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				CONSTRAINT projects_owner_name_uk UNIQUE (owner, name)
+			)`),
+			oracleIgnoreExists(`ALTER TABLE tasks ADD (project_id NUMBER REFERENCES projects (id))`),
+			oracleIgnoreExists(`ALTER TABLE tasks ADD (archived NUMBER(1) DEFAULT 0 NOT NULL)`),
+			oracleIgnoreExists(`CREATE INDEX tasks_project_id_idx ON tasks (project_id)`),
+		},
+		SQLite: []string{
+			`CREATE TABLE IF NOT EXISTS projects (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				owner VARCHAR(100) NOT NULL,
+				name VARCHAR(100) NOT NULL,
+				color VARCHAR(7),
+				archived INTEGER NOT NULL DEFAULT 0,
+				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				UNIQUE (owner, name)
+			)`,
+			`ALTER TABLE tasks ADD COLUMN project_id INTEGER REFERENCES projects (id)`,
+			`ALTER TABLE tasks ADD COLUMN archived INTEGER NOT NULL DEFAULT 0`,
+			`CREATE INDEX IF NOT EXISTS tasks_project_id_idx ON tasks (project_id)`,
+		},
+	},
+	{
+		// parent_id tanpa ON DELETE: subtask dilepas dari parent yang dihapus
+		// oleh repository agar versinya ikut naik.
+		Version: 9,
+		Name:    "create_subtasks_and_checklists",
+		Postgres: []string{
+			`ALTER TABLE tasks ADD COLUMN IF NOT EXISTS parent_id INTEGER REFERENCES tasks (id)`,
+			`ALTER TABLE tasks ADD COLUMN IF NOT EXISTS auto_complete SMALLINT NOT NULL DEFAULT 0`,
+			`CREATE INDEX IF NOT EXISTS tasks_parent_id_idx ON tasks (parent_id)`,
+			`CREATE TABLE IF NOT EXISTS checklist_items (
+				id SERIAL PRIMARY KEY,
+				task_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+				text VARCHAR(200) NOT NULL,
+				done SMALLINT NOT NULL DEFAULT 0,
+				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+			)`,
+			`CREATE INDEX IF NOT EXISTS checklist_items_task_id_idx ON checklist_items (task_id)`,
+		},
+		Oracle: []string{
+			oracleIgnoreExists(`ALTER TABLE tasks ADD (parent_id NUMBER REFERENCES tasks (id))`),
+			oracleIgnoreExists(`ALTER TABLE tasks ADD (auto_complete NUMBER(1) DEFAULT 0 NOT NULL)`),
+			oracleIgnoreExists(`CREATE INDEX tasks_parent_id_idx ON tasks (parent_id)`),
+			oracleIgnoreExists(`CREATE TABLE checklist_items (
+				id NUMBER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+				task_id NUMBER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+				text VARCHAR2(200) NOT NULL,
+				done NUMBER(1) DEFAULT 0 NOT NULL,
+				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+			)`),
+			oracleIgnoreExists(`CREATE INDEX checklist_items_task_id_idx ON checklist_items (task_id)`),
+		},
+		SQLite: []string{
+			`ALTER TABLE tasks ADD COLUMN parent_id INTEGER REFERENCES tasks (id)`,
+			`ALTER TABLE tasks ADD COLUMN auto_complete INTEGER NOT NULL DEFAULT 0`,
+			`CREATE INDEX IF NOT EXISTS tasks_parent_id_idx ON tasks (parent_id)`,
+			`CREATE TABLE IF NOT EXISTS checklist_items (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				task_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+				text VARCHAR(200) NOT NULL,
+				done INTEGER NOT NULL DEFAULT 0,
+				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+			)`,
+			`CREATE INDEX IF NOT EXISTS checklist_items_task_id_idx ON checklist_items (task_id)`,
+		},
+	},
+	{
+		// Baris (task_id, blocker_id) berarti task_id diblokir oleh
+		// blocker_id. Indeks blocker_id dipakai untuk menelusuri task yang
+		// diblokir; primary key melayani arah sebaliknya.
+		Version: 10,
+		Name:    "create_task_dependencies",
+		Postgres: []string{
+			`CREATE TABLE IF NOT EXISTS task_dependencies (
+				task_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+				blocker_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (task_id, blocker_id)
+			)`,
+			`CREATE INDEX IF NOT EXISTS task_dependencies_blocker_id_idx ON task_dependencies (blocker_id)`,
+		},
+		Oracle: []string{
+			oracleIgnoreExists(`CREATE TABLE task_dependencies (
+				task_id NUMBER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+				blocker_id NUMBER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (task_id, blocker_id)
+			)`),
+			oracleIgnoreExists(`CREATE INDEX task_dependencies_blocker_id_idx ON task_dependencies (blocker_id)`),
+		},
+		SQLite: []string{
+			`CREATE TABLE IF NOT EXISTS task_dependencies (
+				task_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+				blocker_id INTEGER NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+				created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+				PRIMARY KEY (task_id, blocker_id)
+			)`,
+			`CREATE INDEX IF NOT EXISTS task_dependencies_blocker_id_idx ON task_dependencies (blocker_id)`,
+		},
+	},
+}
+
+// Latest mengembalikan versi skema yang diharapkan oleh binary ini.
+func Latest() int {
+	return all[len(all)-1].Version
+}
+
+// Version mengembalikan versi skema yang sudah diterapkan di database.
+func Version(ctx context.Context, db *sql.DB) (int, error) {
+	var version sql.NullInt64
+	if err := db.QueryRowContext(ctx, "SELECT MAX(version) FROM schema_migrations").Scan(&version); err != nil {
+		return 0, fmt.Errorf("read schema version: %w", err)
+	}
+	return int(version.Int64), nil
+}
+
+// Up menerapkan semua migrasi yang belum dijalankan secara berurutan.
+func Up(ctx context.Context, db *sql.DB, dialect string) error {
+	if err := ensureVersionTable(ctx, db); err != nil {
+		return err
+	}
+
+	current, err := Version(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range all {
+		if m.Version <= current {
+			continue
+		}
+
+		var statements []string
+		switch dialect {
+		case "postgres":
+			statements = m.Postgres
+		case "oracle":
+			statements = m.Oracle
+		case "sqlite":
+			statements = m.SQLite
+		default:
+			return fmt.Errorf("unsupported migration dialect %q", dialect)
+		}
+
+		for _, stmt := range statements {
+			if _, err := db.ExecContext(ctx, stmt); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", m.Version, m.Name, err)
+			}
+		}
+
+		// Versi adalah konstanta integer, aman disisipkan langsung tanpa placeholder
+		// sehingga query ini sama untuk semua dialek.
+		if _, err := db.ExecContext(ctx, fmt.Sprintf("INSERT INTO schema_migrations (version) VALUES (%d)", m.Version)); err != nil {
+			return fmt.Errorf("record migration %d_%s: %w", m.Version, m.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func ensureVersionTable(ctx context.Context, db *sql.DB) error {
+	if _, err := db.ExecContext(ctx, "SELECT COUNT(*) FROM schema_migrations"); err == nil {
+		return nil
+	}
+
+	_, err := db.ExecContext(ctx, "CREATE TABLE schema_migrations (version INTEGER PRIMARY KEY, applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP)")
+	if err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+// models/checklist.go
+package models
+
+// ChecklistItem adalah langkah ringan di dalam satu task, tanpa status,
+// tanggal atau tag sendiri seperti subtask. Checklist termasuk representasi
+// task sehingga perubahannya menaikkan versi task.
+type ChecklistItem struct {
+	ID   uint   `json:"id"`
+	Text string `json:"text" validate:"required,max=200"`
+	Done bool   `json:"done"`
+}
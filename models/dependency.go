@@ -0,0 +1,9 @@
+// models/dependency.go
+package models
+
+// Dependency menyatakan bahwa task TaskID diblokir oleh task BlockerID:
+// TaskID baru boleh diselesaikan setelah BlockerID completed.
+type Dependency struct {
+	TaskID    uint `json:"task_id"`
+	BlockerID uint `json:"blocker_id"`
+}
@@ -0,0 +1,17 @@
+// models/project.go
+package models
+
+import "time"
+
+// Project mengelompokkan task. Task di project yang diarsipkan ikut
+// ditandai Archived dan disembunyikan dari daftar task bawaan.
+type Project struct {
+	ID    uint   `json:"id"`
+	Owner string `json:"owner"`
+	Name  string `json:"name" validate:"required,max=100"`
+	// Color adalah warna tampilan dalam format hex, mis. "#1e90ff".
+	Color     string    `json:"color" validate:"omitempty,hexcolor"`
+	Archived  bool      `json:"archived"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
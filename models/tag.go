@@ -0,0 +1,19 @@
+// models/tag.go
+package models
+
+import "strings"
+
+// Tag adalah label yang bisa dipasang ke banyak task. Nama tag unik dan
+// selalu disimpan dalam bentuk NormalizeTagName. Koma tidak diizinkan
+// karena parameter tags memakai koma sebagai pemisah. Task.Tags hanya
+// diubah lewat endpoint tag, bukan lewat create, update atau patch task.
+type Tag struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name" validate:"required,max=50,excludesall=0x2C"`
+}
+
+// NormalizeTagName merapikan nama tag agar "Infra " dan "infra" dianggap
+// tag yang sama.
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -2,18 +2,57 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
 )
 
 type Task struct {
-	ID          uint           `json:"id" gorm:"primaryKey"`
-	Title       string         `json:"title" validate:"required"`
-	Description string         `json:"description"`
-	Status      string         `json:"status" validate:"oneof=pending completed"`
-	DueDate     time.Time      `json:"due_date" validate:"required"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	Title       string    `json:"title" validate:"required"`
+	Description string    `json:"description"`
+	Status      string    `json:"status" validate:"oneof=pending completed"`
+	DueDate     time.Time `json:"due_date" validate:"required"`
+	Priority    int       `json:"priority" validate:"min=0,max=3"`
+	Version     uint      `json:"version"`
+	Tags        []Tag     `json:"tags"`
+	ProjectID   *uint     `json:"project_id"`
+	Archived    bool      `json:"archived"`
+	ParentID    *uint     `json:"parent_id"`
+	// AutoComplete menyelesaikan task ini otomatis saat subtask terakhirnya
+	// diselesaikan.
+	AutoComplete bool            `json:"auto_complete"`
+	Checklist    []ChecklistItem `json:"checklist"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt  `json:"-" gorm:"index"`
+}
+
+// Progress adalah kemajuan parent yang dihitung dari subtask langsungnya.
+type Progress struct {
+	Completed int `json:"completed"`
+	Total     int `json:"total"`
+	Percent   int `json:"percent"`
+}
+
+// SubtaskProgress menghitung Progress dari subtasks; Percent dibulatkan ke
+// bawah dan 0 jika tidak ada subtask.
+func SubtaskProgress(subtasks []Task) Progress {
+	p := Progress{Total: len(subtasks)}
+	for _, task := range subtasks {
+		if task.Status == "completed" {
+			p.Completed++
+		}
+	}
+	if p.Total > 0 {
+		p.Percent = p.Completed * 100 / p.Total
+	}
+	return p
+}
+
+// ETag mengembalikan entity tag kuat untuk representasi task saat ini.
+// Version naik setiap kali task diubah sehingga ETag ikut berubah.
+func (t Task) ETag() string {
+	return fmt.Sprintf(`"%d-%d"`, t.ID, t.Version)
 }
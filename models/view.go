@@ -0,0 +1,37 @@
+// models/view.go
+package models
+
+import "time"
+
+// View adalah kombinasi parameter GET /api/tasks yang disimpan dengan nama
+// oleh seorang user (Owner) dan bisa dibagikan ke user lain.
+type View struct {
+	ID    uint   `json:"id"`
+	Owner string `json:"owner"`
+	Name  string `json:"name" validate:"required,max=100"`
+	// Filter berisi ekspresi bahasa filter (parameter q).
+	Filter   string `json:"filter"`
+	Search   string `json:"search"`
+	Sort     string `json:"sort"`
+	PageSize int    `json:"page_size" validate:"min=0,max=100"`
+	// SharedWith adalah username lain yang boleh memakai view ini.
+	SharedWith []string `json:"shared_with" validate:"max=50,dive,required,max=100"`
+	// IsDefault bernilai true jika view ini adalah view bawaan user yang
+	// meminta; tidak disimpan di tabel views.
+	IsDefault bool      `json:"is_default"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// VisibleTo melaporkan apakah username boleh membaca dan memakai view.
+func (v View) VisibleTo(username string) bool {
+	if v.Owner == username {
+		return true
+	}
+	for _, shared := range v.SharedWith {
+		if shared == username {
+			return true
+		}
+	}
+	return false
+}
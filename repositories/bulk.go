@@ -0,0 +1,126 @@
+// repositories/bulk.go
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type BulkOp string
+
+const (
+	BulkCreate BulkOp = "create"
+	BulkUpdate BulkOp = "update"
+	BulkDelete BulkOp = "delete"
+)
+
+// BulkOperation adalah satu langkah di request bulk. Untuk update dan delete
+// Task.ID wajib diisi; Task.Version > 0 mengaktifkan pemeriksaan versi
+// seperti If-Match.
+type BulkOperation struct {
+	Op   BulkOp
+	Task models.Task
+}
+
+// BulkResult adalah hasil satu operasi: Task berisi data terbaru untuk
+// create dan update (nil untuk delete), atau Err jika operasi gagal.
+type BulkResult struct {
+	Task *models.Task
+	Err  error
+}
+
+// BulkError dikembalikan pada mode atomic: operasi ke-Index gagal dan
+// seluruh transaksi sudah di-rollback.
+type BulkError struct {
+	Index int
+	Err   error
+}
+
+func (e *BulkError) Error() string {
+	return fmt.Sprintf("bulk operation %d: %v", e.Index, e.Err)
+}
+
+func (e *BulkError) Unwrap() error {
+	return e.Err
+}
+
+// BulkHook dijalankan di dalam transaksi BulkWrite setelah setiap operasi
+// yang berhasil, dengan repository transaksi tersebut. Pada mode per-item
+// hook ikut berada di savepoint operasinya, sehingga error hook hanya
+// menggagalkan operasi itu.
+type BulkHook func(ctx context.Context, tasks TaskRepository, task *models.Task) error
+
+// bulkSavepoint dipakai ulang untuk setiap operasi pada mode per-item.
+// Postgres dan Oracle sama-sama mengizinkan nama savepoint yang sama
+// ditimpa oleh savepoint berikutnya.
+const bulkSavepoint = "bulk_item"
+
+func (r *taskRepository) BulkWrite(ctx context.Context, ops []BulkOperation, atomic bool, after BulkHook) (_ []BulkResult, err error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.BulkWrite", trace.WithAttributes(
+		attribute.Int("bulk.size", len(ops)),
+		attribute.Bool("bulk.atomic", atomic),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	results := make([]BulkResult, len(ops))
+	err = r.transaction(ctx, func(tx *taskRepository) error {
+		for i, op := range ops {
+			if !atomic {
+				if _, err := tx.q.ExecContext(ctx, "SAVEPOINT "+bulkSavepoint); err != nil {
+					return err
+				}
+			}
+
+			task, err := tx.applyBulk(ctx, op)
+			if err == nil && task != nil && after != nil {
+				err = after(ctx, tx, task)
+			}
+			if err != nil {
+				if atomic {
+					return &BulkError{Index: i, Err: err}
+				}
+				if _, rbErr := tx.q.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+bulkSavepoint); rbErr != nil {
+					return rbErr
+				}
+				results[i].Err = err
+				continue
+			}
+			results[i].Task = task
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// applyBulk menjalankan satu operasi; cache dibuang setelah commit.
+func (r *taskRepository) applyBulk(ctx context.Context, op BulkOperation) (*models.Task, error) {
+	task := op.Task
+	switch op.Op {
+	case BulkCreate:
+		if err := r.insertTask(ctx, &task); err != nil {
+			return nil, err
+		}
+		return &task, nil
+	case BulkUpdate:
+		if err := r.updateTask(ctx, &task); err != nil {
+			return nil, err
+		}
+		r.invalidateCache(ctx, task.ID)
+		return r.selectTask(ctx, task.ID, "")
+	case BulkDelete:
+		if err := r.deleteTask(ctx, task.ID, task.Version); err != nil {
+			return nil, err
+		}
+		r.invalidateCache(ctx, task.ID)
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unknown bulk operation %q", op.Op)
+}
@@ -0,0 +1,123 @@
+// repositories/bulk_filter.go
+package repositories
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/filter"
+	"github.com/programmercintasunnah/go-todolist-ilcs/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TaskChanges adalah field yang diubah oleh UpdateByFilter; nil berarti
+// field tersebut tidak diubah.
+type TaskChanges struct {
+	Status  *string
+	DueDate *time.Time
+}
+
+// maxInListSize menjaga IN (...) di bawah batas 1000 ekspresi Oracle.
+const maxInListSize = 500
+
+// UpdateByFilter mengubah semua task yang cocok dengan where (bahasa filter
+// yang sama dengan GetAllTasks) dan mengembalikan ID-nya. Dengan dryRun tidak
+// ada yang diubah; hasilnya hanya ID yang akan terkena.
+func (r *taskRepository) UpdateByFilter(ctx context.Context, where filter.Expr, changes TaskChanges, dryRun bool) (_ []uint, err error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.UpdateByFilter", trace.WithAttributes(attribute.Bool("bulk.dry_run", dryRun)))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	set := "updated_at = ?, version = version + 1"
+	setArgs := []interface{}{time.Now()}
+	var before func(ctx context.Context, tx *taskRepository, chunk, ids []uint) error
+	if changes.Status != nil {
+		set += ", status = ?"
+		setArgs = append(setArgs, *changes.Status)
+		if *changes.Status == "completed" {
+			before = requireUnblocked
+		}
+	}
+	if changes.DueDate != nil {
+		set += ", due_date = ?"
+		setArgs = append(setArgs, *changes.DueDate)
+	}
+
+	ids, err := r.writeByFilter(ctx, where, dryRun, "UPDATE tasks SET "+set, setArgs, before)
+	span.SetAttributes(attribute.Int("bulk.affected", len(ids)))
+	return ids, err
+}
+
+// DeleteByFilter menghapus semua task yang cocok dengan filter; lihat UpdateByFilter.
+func (r *taskRepository) DeleteByFilter(ctx context.Context, where filter.Expr, dryRun bool) (_ []uint, err error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.DeleteByFilter", trace.WithAttributes(attribute.Bool("bulk.dry_run", dryRun)))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	ids, err := r.writeByFilter(ctx, where, dryRun, "DELETE FROM tasks", nil, func(ctx context.Context, tx *taskRepository, chunk, _ []uint) error {
+		return detachSubtasks(ctx, tx, chunk)
+	})
+	span.SetAttributes(attribute.Int("bulk.affected", len(ids)))
+	return ids, err
+}
+
+// writeByFilter mengunci task yang cocok (SELECT ... FOR UPDATE) lalu
+// menjalankan statement hanya untuk ID tersebut, sehingga yang berubah
+// persis sama dengan yang dilaporkan walaupun ada insert bersamaan. Dry run
+// hanya membaca sehingga transaksinya tidak mengubah apa pun. before (boleh
+// nil) dijalankan untuk setiap potongan ID sebelum statement, bersama semua
+// ID yang akan diubah (urut naik).
+func (r *taskRepository) writeByFilter(ctx context.Context, where filter.Expr, dryRun bool, statement string, statementArgs []interface{},
+	before func(ctx context.Context, tx *taskRepository, chunk, ids []uint) error) ([]uint, error) {
+	whereSQL, args, err := r.dialect.taskWhere(where)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []uint{}
+	err = r.transaction(ctx, func(tx *taskRepository) error {
+		rows, err := tx.q.QueryContext(ctx, r.dialect.Rebind("SELECT id FROM tasks"+whereSQL+" ORDER BY id"+r.dialect.ForUpdate()), args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var id uint
+			if err := rows.Scan(&id); err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		rows.Close()
+
+		if dryRun {
+			return nil
+		}
+
+		for start := 0; start < len(ids); start += maxInListSize {
+			chunk := ids[start:min(start+maxInListSize, len(ids))]
+			if before != nil {
+				if err := before(ctx, tx, chunk, ids); err != nil {
+					return err
+				}
+			}
+			chunkArgs := append([]interface{}(nil), statementArgs...)
+			for _, id := range chunk {
+				chunkArgs = append(chunkArgs, id)
+				tx.invalidateCache(ctx, id)
+			}
+			query := statement + " WHERE id IN (" + strings.TrimSuffix(strings.Repeat("?, ", len(chunk)), ", ") + ")"
+			if _, err := tx.q.ExecContext(ctx, r.dialect.Rebind(query), chunkArgs...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
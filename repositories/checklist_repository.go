@@ -0,0 +1,179 @@
+// repositories/checklist_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ChecklistRepository mengelola checklist di dalam task. Seperti tag,
+// checklist termasuk representasi task: setiap perubahan menaikkan versi
+// dan updated_at task lalu membuang cache-nya setelah commit. Semua method
+// mengembalikan task terbaru; expectedVersion > 0 berarti hanya jika versi
+// task masih sama.
+type ChecklistRepository interface {
+	// AddItem menambahkan item di akhir checklist lalu mengisi item.ID.
+	AddItem(ctx context.Context, taskID uint, expectedVersion uint, item *models.ChecklistItem) (*models.Task, error)
+	// UpdateItem mengganti teks dan status item.ID.
+	UpdateItem(ctx context.Context, taskID uint, expectedVersion uint, item models.ChecklistItem) (*models.Task, error)
+	DeleteItem(ctx context.Context, taskID uint, expectedVersion uint, itemID uint) (*models.Task, error)
+}
+
+type checklistRepository struct {
+	tasks *taskRepository
+}
+
+// NewChecklistRepository membuat ChecklistRepository di atas tasks agar
+// perubahan checklist memakai cache dan transaksi yang sama dengan task.
+func NewChecklistRepository(tasks TaskStore) ChecklistRepository {
+	return &checklistRepository{tasks: tasks.store()}
+}
+
+// maxChecklistItems membatasi panjang checklist per task.
+const maxChecklistItems = 100
+
+func (r *checklistRepository) AddItem(ctx context.Context, taskID uint, expectedVersion uint, item *models.ChecklistItem) (_ *models.Task, err error) {
+	ctx, span := tracer.Start(ctx, "ChecklistRepository.AddItem", trace.WithAttributes(attribute.Int("task.id", int(taskID))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	var task *models.Task
+	err = r.tasks.transaction(ctx, func(tx *taskRepository) error {
+		current, err := lockTask(ctx, tx, taskID, expectedVersion)
+		if err != nil {
+			return err
+		}
+		if len(current.Checklist) >= maxChecklistItems {
+			return apperrors.Conflict("checklist_full", fmt.Sprintf("Task %d already has %d checklist items", taskID, maxChecklistItems)).
+				WithParams(strconv.FormatUint(uint64(taskID), 10), strconv.Itoa(maxChecklistItems))
+		}
+
+		query := "INSERT INTO checklist_items (task_id, text, done, created_at) VALUES (?, ?, ?, ?)"
+		args := []interface{}{taskID, item.Text, boolInt(item.Done), time.Now()}
+		var id int64
+		switch tx.dialect {
+		case Oracle:
+			query += " RETURNING id INTO ?"
+			_, err = tx.q.ExecContext(ctx, tx.dialect.Rebind(query), append(args, sql.Out{Dest: &id})...)
+		default:
+			query += " RETURNING id"
+			err = tx.q.QueryRowContext(ctx, tx.dialect.Rebind(query), args...).Scan(&id)
+		}
+		if err != nil {
+			return err
+		}
+		item.ID = uint(id)
+
+		task, err = finishTaskChange(ctx, tx, current, true)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (r *checklistRepository) UpdateItem(ctx context.Context, taskID uint, expectedVersion uint, item models.ChecklistItem) (_ *models.Task, err error) {
+	ctx, span := tracer.Start(ctx, "ChecklistRepository.UpdateItem", trace.WithAttributes(
+		attribute.Int("task.id", int(taskID)),
+		attribute.Int("checklist_item.id", int(item.ID)),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return r.change(ctx, taskID, expectedVersion, item.ID,
+		"UPDATE checklist_items SET text = ?, done = ? WHERE id = ? AND task_id = ?", item.Text, boolInt(item.Done), item.ID, taskID)
+}
+
+func (r *checklistRepository) DeleteItem(ctx context.Context, taskID uint, expectedVersion uint, itemID uint) (_ *models.Task, err error) {
+	ctx, span := tracer.Start(ctx, "ChecklistRepository.DeleteItem", trace.WithAttributes(
+		attribute.Int("task.id", int(taskID)),
+		attribute.Int("checklist_item.id", int(itemID)),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return r.change(ctx, taskID, expectedVersion, itemID, "DELETE FROM checklist_items WHERE id = ? AND task_id = ?", itemID, taskID)
+}
+
+// change menjalankan statement untuk satu item milik task, atau
+// checklist_item_not_found jika item itu bukan milik task.
+func (r *checklistRepository) change(ctx context.Context, taskID uint, expectedVersion uint, itemID uint, query string, args ...interface{}) (*models.Task, error) {
+	var task *models.Task
+	err := r.tasks.transaction(ctx, func(tx *taskRepository) error {
+		current, err := lockTask(ctx, tx, taskID, expectedVersion)
+		if err != nil {
+			return err
+		}
+		result, err := tx.q.ExecContext(ctx, tx.dialect.Rebind(query), args...)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return checklistItemNotFound(taskID, itemID)
+		}
+
+		task, err = finishTaskChange(ctx, tx, current, true)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// loadChecklists mengisi Checklist semua tasks dengan satu query per
+// maxInListSize task, seperti loadTags.
+func (r *taskRepository) loadChecklists(ctx context.Context, tasks []models.Task) error {
+	index := make(map[uint]int, len(tasks))
+	for i := range tasks {
+		tasks[i].Checklist = []models.ChecklistItem{}
+		index[tasks[i].ID] = i
+	}
+
+	for start := 0; start < len(tasks); start += maxInListSize {
+		chunk := tasks[start:min(start+maxInListSize, len(tasks))]
+		args := make([]interface{}, len(chunk))
+		for i, task := range chunk {
+			args[i] = task.ID
+		}
+		query := "SELECT task_id, id, text, done FROM checklist_items WHERE task_id IN (" +
+			strings.TrimSuffix(strings.Repeat("?, ", len(chunk)), ", ") + ") ORDER BY id"
+		if err := r.scanChecklist(ctx, query, args, tasks, index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *taskRepository) scanChecklist(ctx context.Context, query string, args []interface{}, tasks []models.Task, index map[uint]int) error {
+	rows, err := r.q.QueryContext(ctx, r.dialect.Rebind(query), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var taskID uint
+		var item models.ChecklistItem
+		if err := rows.Scan(&taskID, &item.ID, &item.Text, &item.Done); err != nil {
+			return err
+		}
+		i := index[taskID]
+		tasks[i].Checklist = append(tasks[i].Checklist, item)
+	}
+	return rows.Err()
+}
+
+func checklistItemNotFound(taskID, itemID uint) error {
+	return apperrors.NotFound("checklist_item_not_found", fmt.Sprintf("Task %d does not have checklist item %d", taskID, itemID)).
+		WithParams(strconv.FormatUint(uint64(taskID), 10), strconv.FormatUint(uint64(itemID), 10))
+}
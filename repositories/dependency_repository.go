@@ -0,0 +1,337 @@
+// repositories/dependency_repository.go
+package repositories
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DependencyNode adalah task di DependencyGraph.
+type DependencyNode struct {
+	ID     uint
+	Title  string
+	Status string
+}
+
+// DependencyGraph adalah semua task yang memblokir TaskID (langsung maupun
+// tidak) dan semua task yang diblokirnya. BlockedBy dan Blocking hanya
+// berisi tetangga langsung; Edges berisi seluruh sisi graf.
+type DependencyGraph struct {
+	TaskID    uint
+	BlockedBy []uint
+	Blocking  []uint
+	Nodes     []DependencyNode
+	Edges     []models.Dependency
+}
+
+// DependencyRepository mengelola dependensi antar task. Dependensi tidak
+// termasuk representasi task sehingga versi task tidak berubah. Baris milik
+// task yang terhapus tanpa cascade (SQLite tanpa PRAGMA foreign_keys)
+// diabaikan oleh semua query.
+type DependencyRepository interface {
+	// AddDependency mencatat taskID diblokir oleh blockerID. Dependensi yang
+	// akan membentuk siklus ditolak dengan ErrConflict.
+	AddDependency(ctx context.Context, taskID, blockerID uint) error
+	RemoveDependency(ctx context.Context, taskID, blockerID uint) error
+	Graph(ctx context.Context, taskID uint) (*DependencyGraph, error)
+}
+
+type dependencyRepository struct {
+	tasks *taskRepository
+}
+
+// NewDependencyRepository membuat DependencyRepository di atas tasks.
+func NewDependencyRepository(tasks TaskStore) DependencyRepository {
+	return &dependencyRepository{tasks: tasks.store()}
+}
+
+// Kondisi JOIN agar dependensi milik task yang sudah terhapus diabaikan.
+const (
+	blockersQuery = "SELECT d.task_id, d.blocker_id FROM task_dependencies d JOIN tasks b ON b.id = d.blocker_id WHERE d.task_id IN "
+	blockingQuery = "SELECT d.task_id, d.blocker_id FROM task_dependencies d JOIN tasks t ON t.id = d.task_id WHERE d.blocker_id IN "
+)
+
+func (r *dependencyRepository) AddDependency(ctx context.Context, taskID, blockerID uint) (err error) {
+	ctx, span := tracer.Start(ctx, "DependencyRepository.AddDependency", trace.WithAttributes(
+		attribute.Int("task.id", int(taskID)),
+		attribute.Int("task.blocker_id", int(blockerID)),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if taskID == blockerID {
+		return dependencyCycle(taskID, blockerID)
+	}
+
+	return r.tasks.transaction(ctx, func(tx *taskRepository) error {
+		// Kunci berurutan menurut ID agar dua link yang berlawanan arah
+		// tidak saling menunggu
+		for _, id := range sortedPair(taskID, blockerID) {
+			if _, err := lockTask(ctx, tx, id, 0); err != nil {
+				return err
+			}
+		}
+		// Dua link dengan pasangan task berbeda bisa bersama-sama menutup
+		// siklus karena masing-masing tidak melihat sisi baru yang lain.
+		// Penulisan dependensi diserialkan dengan mengunci table-nya;
+		// dikunci setelah baris task, urutan yang sama dengan DeleteTask yang
+		// menghapus dependensinya lewat cascade.
+		if lock := tx.dialect.LockTable("task_dependencies"); lock != "" {
+			if _, err := tx.q.ExecContext(ctx, lock); err != nil {
+				return err
+			}
+		}
+
+		exists, err := dependencyExists(ctx, tx, taskID, blockerID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return apperrors.Conflict("dependency_exists", fmt.Sprintf("Task %d is already blocked by task %d", taskID, blockerID)).
+				WithParams(strconv.FormatUint(uint64(taskID), 10), strconv.FormatUint(uint64(blockerID), 10))
+		}
+
+		// taskID tidak boleh (tidak langsung) memblokir blockerID
+		upstream, _, err := walkDependencies(ctx, tx, blockerID, blockersQuery, func(d models.Dependency) uint { return d.BlockerID })
+		if err != nil {
+			return err
+		}
+		if slices.Contains(upstream, taskID) {
+			return dependencyCycle(taskID, blockerID)
+		}
+
+		_, err = tx.q.ExecContext(ctx, tx.dialect.Rebind("INSERT INTO task_dependencies (task_id, blocker_id, created_at) VALUES (?, ?, ?)"),
+			taskID, blockerID, time.Now())
+		return err
+	})
+}
+
+func (r *dependencyRepository) RemoveDependency(ctx context.Context, taskID, blockerID uint) (err error) {
+	ctx, span := tracer.Start(ctx, "DependencyRepository.RemoveDependency", trace.WithAttributes(
+		attribute.Int("task.id", int(taskID)),
+		attribute.Int("task.blocker_id", int(blockerID)),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	result, err := r.tasks.q.ExecContext(ctx, r.tasks.dialect.Rebind("DELETE FROM task_dependencies WHERE task_id = ? AND blocker_id = ?"), taskID, blockerID)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return apperrors.NotFound("dependency_not_found", fmt.Sprintf("Task %d is not blocked by task %d", taskID, blockerID)).
+			WithParams(strconv.FormatUint(uint64(taskID), 10), strconv.FormatUint(uint64(blockerID), 10))
+	}
+	return nil
+}
+
+func (r *dependencyRepository) Graph(ctx context.Context, taskID uint) (_ *DependencyGraph, err error) {
+	ctx, span := tracer.Start(ctx, "DependencyRepository.Graph", trace.WithAttributes(attribute.Int("task.id", int(taskID))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if _, err := r.tasks.GetTaskByID(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	upstream, upEdges, err := walkDependencies(ctx, r.tasks, taskID, blockersQuery, func(d models.Dependency) uint { return d.BlockerID })
+	if err != nil {
+		return nil, err
+	}
+	downstream, downEdges, err := walkDependencies(ctx, r.tasks, taskID, blockingQuery, func(d models.Dependency) uint { return d.TaskID })
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &DependencyGraph{TaskID: taskID, BlockedBy: []uint{}, Blocking: []uint{}}
+	for _, edge := range upEdges {
+		if edge.TaskID == taskID {
+			graph.BlockedBy = append(graph.BlockedBy, edge.BlockerID)
+		}
+	}
+	for _, edge := range downEdges {
+		if edge.BlockerID == taskID {
+			graph.Blocking = append(graph.Blocking, edge.TaskID)
+		}
+	}
+	slices.Sort(graph.BlockedBy)
+	slices.Sort(graph.Blocking)
+	graph.Edges = append(upEdges, downEdges...)
+	slices.SortFunc(graph.Edges, func(a, b models.Dependency) int {
+		return cmp.Or(cmp.Compare(a.TaskID, b.TaskID), cmp.Compare(a.BlockerID, b.BlockerID))
+	})
+
+	ids := append(append([]uint{taskID}, upstream...), downstream...)
+	slices.Sort(ids)
+	if graph.Nodes, err = dependencyNodes(ctx, r.tasks, ids); err != nil {
+		return nil, err
+	}
+	return graph, nil
+}
+
+// walkDependencies menelusuri graf dari start memakai query (blockersQuery
+// atau blockingQuery) dan mengembalikan ID yang dicapai selain start beserta
+// sisi yang dilalui. next memilih ujung sisi yang ditelusuri berikutnya.
+func walkDependencies(ctx context.Context, r *taskRepository, start uint, query string, next func(models.Dependency) uint) ([]uint, []models.Dependency, error) {
+	seen := map[uint]bool{start: true}
+	var reached []uint
+	edges := []models.Dependency{}
+	for frontier := []uint{start}; len(frontier) > 0; {
+		var found []uint
+		for chunkStart := 0; chunkStart < len(frontier); chunkStart += maxInListSize {
+			chunk := frontier[chunkStart:min(chunkStart+maxInListSize, len(frontier))]
+			level, err := queryDependencies(ctx, r, query, chunk)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, edge := range level {
+				edges = append(edges, edge)
+				if id := next(edge); !seen[id] {
+					seen[id] = true
+					found = append(found, id)
+				}
+			}
+		}
+		reached = append(reached, found...)
+		frontier = found
+	}
+	return reached, edges, nil
+}
+
+func queryDependencies(ctx context.Context, r *taskRepository, query string, ids []uint) ([]models.Dependency, error) {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	rows, err := r.q.QueryContext(ctx, r.dialect.Rebind(query+"("+strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")+")"), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deps []models.Dependency
+	for rows.Next() {
+		var dep models.Dependency
+		if err := rows.Scan(&dep.TaskID, &dep.BlockerID); err != nil {
+			return nil, err
+		}
+		deps = append(deps, dep)
+	}
+	return deps, rows.Err()
+}
+
+func dependencyNodes(ctx context.Context, r *taskRepository, ids []uint) ([]DependencyNode, error) {
+	nodes := make([]DependencyNode, 0, len(ids))
+	for start := 0; start < len(ids); start += maxInListSize {
+		chunk := ids[start:min(start+maxInListSize, len(ids))]
+		args := make([]interface{}, len(chunk))
+		for i, id := range chunk {
+			args[i] = id
+		}
+		query := "SELECT id, title, status FROM tasks WHERE id IN (" + strings.TrimSuffix(strings.Repeat("?, ", len(chunk)), ", ") + ") ORDER BY id"
+		rows, err := r.q.QueryContext(ctx, r.dialect.Rebind(query), args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var node DependencyNode
+			if err := rows.Scan(&node.ID, &node.Title, &node.Status); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			nodes = append(nodes, node)
+		}
+		if err := errors.Join(rows.Err(), rows.Close()); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+func dependencyExists(ctx context.Context, tx *taskRepository, taskID, blockerID uint) (bool, error) {
+	var count int
+	err := tx.q.QueryRowContext(ctx, tx.dialect.Rebind("SELECT COUNT(*) FROM task_dependencies WHERE task_id = ? AND blocker_id = ?"), taskID, blockerID).Scan(&count)
+	return count > 0, err
+}
+
+// requireUnblocked menolak menyelesaikan ids jika salah satunya belum
+// completed dan masih diblokir task yang belum completed. Blocker yang ada di
+// completing (urut naik) diabaikan karena ikut diselesaikan oleh statement
+// yang sama. Tidak ada pemeriksaan jika tasks.enforce_dependencies mati.
+func requireUnblocked(ctx context.Context, tx *taskRepository, ids, completing []uint) error {
+	if !tx.enforceDependencies.Load() || len(ids) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	query := "SELECT d.task_id, d.blocker_id FROM task_dependencies d JOIN tasks t ON t.id = d.task_id JOIN tasks b ON b.id = d.blocker_id" +
+		" WHERE d.task_id IN (" + strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ") + ")" +
+		" AND t.status <> 'completed' AND b.status <> 'completed' ORDER BY d.task_id, d.blocker_id"
+	rows, err := tx.q.QueryContext(ctx, tx.dialect.Rebind(query), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var taskID uint
+	var blockers []string
+	for rows.Next() {
+		var dep models.Dependency
+		if err := rows.Scan(&dep.TaskID, &dep.BlockerID); err != nil {
+			return err
+		}
+		if _, found := slices.BinarySearch(completing, dep.BlockerID); found {
+			continue
+		}
+		if taskID == 0 {
+			taskID = dep.TaskID
+		}
+		if dep.TaskID == taskID {
+			blockers = append(blockers, strconv.FormatUint(uint64(dep.BlockerID), 10))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if taskID == 0 {
+		return nil
+	}
+	list := strings.Join(blockers, ", ")
+	return apperrors.Conflict(taskBlockedCode, fmt.Sprintf("Task %d is blocked by unfinished tasks %s", taskID, list)).
+		WithParams(strconv.FormatUint(uint64(taskID), 10), list)
+}
+
+const taskBlockedCode = "task_blocked"
+
+// IsTaskBlocked melaporkan apakah err adalah penolakan dari
+// tasks.enforce_dependencies.
+func IsTaskBlocked(err error) bool {
+	var appErr *apperrors.Error
+	return errors.As(err, &appErr) && appErr.Code == taskBlockedCode
+}
+
+func dependencyCycle(taskID, blockerID uint) error {
+	return apperrors.Conflict("dependency_cycle", fmt.Sprintf("Task %d cannot be blocked by task %d because it would create a cycle", taskID, blockerID)).
+		WithParams(strconv.FormatUint(uint64(taskID), 10), strconv.FormatUint(uint64(blockerID), 10))
+}
+
+func sortedPair(a, b uint) []uint {
+	if a > b {
+		return []uint{b, a}
+	}
+	return []uint{a, b}
+}
@@ -0,0 +1,78 @@
+// repositories/dialect.go
+package repositories
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Dialect adalah jenis database (config database.type). Query di repository
+// ditulis dengan placeholder ? lalu diubah oleh Rebind sesuai driver.
+type Dialect string
+
+const (
+	Postgres Dialect = "postgres"
+	Oracle   Dialect = "oracle"
+	// SQLite hanya untuk tes repository di memori; bukan database.type.
+	SQLite Dialect = "sqlite"
+)
+
+// Rebind mengganti placeholder ? menjadi $1, $2, ... untuk Postgres atau
+// :1, :2, ... untuk Oracle; SQLite memakai ? apa adanya. Tanda ? di dalam
+// string literal tidak diubah.
+func (d Dialect) Rebind(query string) string {
+	prefix := "$"
+	switch d {
+	case Oracle:
+		prefix = ":"
+	case SQLite:
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	inString := false
+	for _, ch := range query {
+		switch {
+		case ch == '\'':
+			inString = !inString
+		case ch == '?' && !inString:
+			n++
+			b.WriteString(prefix + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(ch)
+	}
+	return b.String()
+}
+
+// Paginate menambahkan batas jumlah baris ke query. Oracle tidak mengenal
+// LIMIT sehingga memakai OFFSET ... FETCH NEXT (12c ke atas).
+func (d Dialect) Paginate(query string, args []interface{}, limit, offset int) (string, []interface{}) {
+	if d == Oracle {
+		return query + " OFFSET ? ROWS FETCH NEXT ? ROWS ONLY", append(args, offset, limit)
+	}
+	return query + " LIMIT ? OFFSET ?", append(args, limit, offset)
+}
+
+// ForUpdate mengembalikan klausa penguncian baris untuk SELECT di dalam
+// transaksi. SQLite tidak mengenal FOR UPDATE dan SELECT di transaksinya
+// tidak mengunci apa pun: database baru dikunci untuk menulis pada
+// statement tulis pertama. SQLite hanya dipakai sebagai database tes.
+func (d Dialect) ForUpdate() string {
+	if d == SQLite {
+		return ""
+	}
+	return " FOR UPDATE"
+}
+
+// LockTable mengembalikan statement yang mengunci table sampai transaksi
+// selesai: penulis lain menunggu, pembaca tidak. Mode SHARE ROW EXCLUSIVE
+// bentrok dengan dirinya sendiri dan tersedia di Postgres maupun Oracle.
+// SQLite mengembalikan "" karena penulisnya sudah saling menunggu.
+func (d Dialect) LockTable(table string) string {
+	if d == SQLite {
+		return ""
+	}
+	return "LOCK TABLE " + table + " IN SHARE ROW EXCLUSIVE MODE"
+}
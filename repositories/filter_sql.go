@@ -0,0 +1,132 @@
+// repositories/filter_sql.go
+package repositories
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/filter"
+)
+
+// filterColumns adalah kolom yang boleh muncul di filter.Comparison. Nama
+// kolom dari AST tetap dicocokkan di sini agar tidak pernah masuk SQL apa
+// adanya.
+var filterColumns = map[string]string{
+	"status":      "status",
+	"title":       "title",
+	"description": "COALESCE(description, '')",
+	"priority":    "priority",
+	"due_date":    "due_date",
+	"created_at":  "created_at",
+	"updated_at":  "updated_at",
+	"project_id":  "project_id",
+	"parent_id":   "parent_id",
+	"archived":    "archived",
+}
+
+// tagCondition adalah kondisi SQL untuk perbandingan tag:nama.
+const tagCondition = "id IN (SELECT tt.task_id FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id WHERE tg.name = ?)"
+
+var sqlOps = map[filter.Op]string{
+	filter.OpEq:  " = ?",
+	filter.OpLt:  " < ?",
+	filter.OpLte: " <= ?",
+	filter.OpGt:  " > ?",
+	filter.OpGte: " >= ?",
+}
+
+// compileFilter menerjemahkan AST filter menjadi kondisi SQL berplaceholder
+// ? (di-Rebind oleh pemanggil). Semua nilai dikirim sebagai argumen.
+func (d Dialect) compileFilter(e filter.Expr) (string, []interface{}, error) {
+	switch e := e.(type) {
+	case filter.And:
+		return d.compileList(e.Exprs, " AND ")
+	case filter.Or:
+		return d.compileList(e.Exprs, " OR ")
+	case filter.Not:
+		cond, args, err := d.compileFilter(e.Expr)
+		if err != nil {
+			return "", nil, err
+		}
+		return "NOT (" + cond + ")", args, nil
+	case filter.Text:
+		title, titleArgs := d.contains("title", e.Value)
+		description, descriptionArgs := d.contains(filterColumns["description"], e.Value)
+		return "(" + title + " OR " + description + ")", append(titleArgs, descriptionArgs...), nil
+	case filter.Search:
+		cond, args := d.fullTextCondition(e)
+		return cond, args, nil
+	case filter.Fuzzy:
+		return d.fuzzyCondition(e)
+	case filter.Comparison:
+		if e.Field == "tag" {
+			return tagCondition, []interface{}{e.Value}, nil
+		}
+		column, ok := filterColumns[e.Field]
+		if !ok {
+			return "", nil, fmt.Errorf("unsupported filter field %q", e.Field)
+		}
+		switch e.Field {
+		case "project_id", "parent_id":
+			if e.Value.(int) == 0 {
+				return column + " IS NULL", nil, nil
+			}
+		case "archived":
+			// archived disimpan sebagai 0/1 di semua dialek
+			return column + " = ?", []interface{}{boolInt(e.Value.(bool))}, nil
+		}
+		if e.Field == "title" || e.Field == "description" {
+			cond, args := d.contains(column, e.Value.(string))
+			return cond, args, nil
+		}
+		op, ok := sqlOps[e.Op]
+		if !ok {
+			return "", nil, fmt.Errorf("unsupported filter operator %q", e.Op)
+		}
+		return column + op, []interface{}{e.Value}, nil
+	}
+	return "", nil, fmt.Errorf("unsupported filter expression %T", e)
+}
+
+func (d Dialect) compileList(exprs []filter.Expr, sep string) (string, []interface{}, error) {
+	conds := make([]string, len(exprs))
+	var args []interface{}
+	for i, sub := range exprs {
+		cond, subArgs, err := d.compileFilter(sub)
+		if err != nil {
+			return "", nil, err
+		}
+		conds[i] = cond
+		args = append(args, subArgs...)
+	}
+	return "(" + strings.Join(conds, sep) + ")", args, nil
+}
+
+// likeEscaper meng-escape wildcard LIKE agar nilai dicari apa adanya.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// contains mencocokkan substring tanpa membedakan huruf besar/kecil.
+func (d Dialect) contains(column, value string) (string, []interface{}) {
+	pattern := "%" + likeEscaper.Replace(value) + "%"
+	return d.likeFold(column), []interface{}{strings.ToLower(pattern)}
+}
+
+// likeFold adalah kondisi LIKE tanpa membedakan huruf besar/kecil untuk pola
+// huruf kecil. Postgres memakai ILIKE (bisa memakai indeks trigram); Oracle
+// dan SQLite memakai LOWER pada kolom. ESCAPE ditulis eksplisit karena
+// Oracle dan SQLite tidak punya karakter escape bawaan.
+func (d Dialect) likeFold(column string) string {
+	if d == Postgres {
+		return column + ` ILIKE ? ESCAPE '\'`
+	}
+	return "LOWER(" + column + `) LIKE ? ESCAPE '\'`
+}
+
+// boolInt mengubah flag menjadi 0/1 untuk kolom flag seperti archived,
+// karena Oracle tidak punya tipe boolean.
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,136 @@
+// repositories/fulltext.go
+package repositories
+
+import (
+	"html"
+	"strings"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/filter"
+)
+
+// SearchHit adalah relevansi dan cuplikan satu task untuk pencarian
+// full-text. Snippet berupa HTML: teks task sudah di-escape dan kata yang
+// cocok dibungkus <mark>.
+type SearchHit struct {
+	Rank    float64
+	Snippet string
+}
+
+// Penanda sorotan dari database; diganti <mark> setelah teks di-escape agar
+// isi task tidak pernah tampil sebagai HTML.
+const (
+	markStart = "{{mark}}"
+	markEnd   = "{{/mark}}"
+)
+
+// RelevanceSort adalah field sort untuk urutan relevansi pencarian. Hanya
+// berlaku bersama search dan tidak bisa dipakai untuk keyset pagination.
+const RelevanceSort = "relevance"
+
+// oracleTextReserved adalah kata operator Oracle Text yang harus di-escape.
+var oracleTextReserved = map[string]bool{
+	"about": true, "accum": true, "and": true, "bt": true, "btg": true, "bti": true, "btp": true,
+	"equiv": true, "fuzzy": true, "haspath": true, "inpath": true, "minus": true, "near": true,
+	"not": true, "nt": true, "ntg": true, "nti": true, "ntp": true, "or": true, "pt": true,
+	"rt": true, "sqe": true, "syn": true, "tr": true, "trsyn": true, "tt": true, "within": true,
+}
+
+// fullTextQuery menyusun query pencarian dalam sintaks mesin full-text tiap
+// dialek. Kata dari filter.Search hanya berisi huruf dan angka sehingga
+// tidak bisa menyisipkan operator.
+func (d Dialect) fullTextQuery(s filter.Search) string {
+	var positive, negative []string
+	for _, term := range s.Terms {
+		var t string
+		switch d {
+		case Postgres:
+			// to_tsquery: frasa memakai <->, pengecualian memakai !
+			t = strings.Join(term.Words, " <-> ")
+			if len(term.Words) > 1 {
+				t = "(" + t + ")"
+			}
+			if term.Exclude {
+				t = "!" + t
+			}
+		case Oracle:
+			// CONTAINS: $ untuk stemming, {} untuk kata operator
+			words := make([]string, len(term.Words))
+			for i, word := range term.Words {
+				if oracleTextReserved[word] {
+					words[i] = "{" + word + "}"
+				} else {
+					words[i] = "$" + word
+				}
+			}
+			t = "(" + strings.Join(words, " ") + ")"
+		default:
+			// FTS5: string dalam kutip adalah kata atau frasa
+			t = `"` + strings.Join(term.Words, " ") + `"`
+		}
+		if term.Exclude && d != Postgres {
+			negative = append(negative, t)
+		} else {
+			positive = append(positive, t)
+		}
+	}
+
+	switch d {
+	case Postgres:
+		return strings.Join(positive, " & ")
+	case Oracle:
+		q := "(" + strings.Join(positive, " & ") + ")"
+		for _, t := range negative {
+			q += " ~ " + t
+		}
+		return q
+	}
+	q := "(" + strings.Join(positive, " AND ") + ")"
+	for _, t := range negative {
+		q += " NOT " + t
+	}
+	return q
+}
+
+// fullTextCondition adalah kondisi WHERE untuk filter.Search. Oracle memberi
+// label 1 pada CONTAINS agar SCORE(1) bisa dipakai di searchColumns.
+func (d Dialect) fullTextCondition(s filter.Search) (string, []interface{}) {
+	query := d.fullTextQuery(s)
+	switch d {
+	case Postgres:
+		return "search_vector @@ to_tsquery('english', ?)", []interface{}{query}
+	case Oracle:
+		return "CONTAINS(title, ?, 1) > 0", []interface{}{query}
+	}
+	return "id IN (SELECT rowid FROM tasks_fts WHERE tasks_fts MATCH ?)", []interface{}{query}
+}
+
+// rankColumns adalah kolom tambahan search_rank (makin besar makin relevan)
+// dan search_snippet untuk SELECT daftar task, dari node hasil
+// filter.Ranking. Fuzzy hanya dikompilasi untuk Postgres (lihat fuzzyTasks).
+func (d Dialect) rankColumns(ranking filter.Expr) (string, []interface{}) {
+	if f, ok := ranking.(filter.Fuzzy); ok {
+		return ", word_similarity(?, title) AS search_rank, NULL AS search_snippet", []interface{}{f.Value}
+	}
+	query := d.fullTextQuery(ranking.(filter.Search))
+	switch d {
+	case Postgres:
+		return ", ts_rank_cd(search_vector, to_tsquery('english', ?)) AS search_rank" +
+				", ts_headline('english', title || ' ' || COALESCE(description, ''), to_tsquery('english', ?)," +
+				" 'StartSel=\"" + markStart + "\", StopSel=\"" + markEnd + "\", MaxFragments=2, MinWords=5, MaxWords=20') AS search_snippet",
+			[]interface{}{query, query}
+	case Oracle:
+		return ", SCORE(1) AS search_rank" +
+				", CTX_DOC.SNIPPET('TASKS_SEARCH_IDX', ROWID, ?, '" + markStart + "', '" + markEnd + "') AS search_snippet",
+			[]interface{}{query}
+	}
+	// bm25 bernilai negatif (makin kecil makin relevan); title diberi bobot lebih
+	return ", (SELECT -bm25(tasks_fts, 10.0, 1.0) FROM tasks_fts WHERE tasks_fts MATCH ? AND rowid = tasks.id) AS search_rank" +
+			", (SELECT snippet(tasks_fts, -1, '" + markStart + "', '" + markEnd + "', '…', 16) FROM tasks_fts WHERE tasks_fts MATCH ? AND rowid = tasks.id) AS search_snippet",
+		[]interface{}{query, query}
+}
+
+// highlight meng-escape snippet lalu mengganti penanda sorotan dengan <mark>.
+func highlight(snippet string) string {
+	escaped := html.EscapeString(snippet)
+	return strings.NewReplacer(markStart, "<mark>", markEnd, "</mark>").Replace(escaped)
+}
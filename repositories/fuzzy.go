@@ -0,0 +1,194 @@
+// repositories/fuzzy.go
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/filter"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxFuzzyCandidates membatasi jumlah baris yang dinilai di proses untuk
+// dialek tanpa pg_trgm. Filter yang menyisakan lebih banyak baris ditolak
+// karena peringkat dan total tidak bisa dihitung dengan benar.
+const maxFuzzyCandidates = 10000
+
+const fuzzyTooBroadCode = "fuzzy_too_broad"
+
+// TitleSuggestion adalah satu usulan title untuk type-ahead. Score bernilai
+// 1 untuk kecocokan awalan dan kemiripan trigram untuk kecocokan fuzzy.
+type TitleSuggestion struct {
+	ID    uint
+	Title string
+	Score float64
+}
+
+// fuzzyCondition adalah kondisi WHERE untuk filter.Fuzzy memakai pg_trgm.
+// Operator <% memakai indeks trigram dengan batas
+// pg_trgm.word_similarity_threshold, yang diset sama dengan
+// filter.FuzzyThreshold saat koneksi dibuka (lihat PostgresOptions). Dialek
+// lain tidak punya padanannya; Fuzzy di puncak filter dinilai di proses
+// oleh fuzzyTasks, di posisi lain ditolak.
+func (d Dialect) fuzzyCondition(f filter.Fuzzy) (string, []interface{}, error) {
+	if d != Postgres {
+		return "", nil, apperrors.Validation("fuzzy_not_supported", fmt.Sprintf("Fuzzy search cannot be combined with OR or NOT on %s", d)).
+			WithParams(string(d))
+	}
+	return "? <% title", []interface{}{f.Value}, nil
+}
+
+// PostgresOptions adalah parameter options koneksi Postgres yang dibutuhkan
+// repository: batas kemiripan operator <% disamakan dengan
+// filter.FuzzyThreshold.
+func PostgresOptions() string {
+	return "-c pg_trgm.word_similarity_threshold=" + strconv.FormatFloat(filter.FuzzyThreshold, 'f', -1, 64)
+}
+
+// splitFuzzy memisahkan Fuzzy yang menjadi peringkat (lihat filter.Ranking)
+// dari sisa filter.
+func splitFuzzy(where filter.Expr) (filter.Fuzzy, filter.Expr, bool) {
+	fuzzy, ok := filter.Ranking(where).(filter.Fuzzy)
+	if !ok {
+		return filter.Fuzzy{}, nil, false
+	}
+	and, ok := where.(filter.And)
+	if !ok {
+		return fuzzy, nil, true
+	}
+	rest := make([]filter.Expr, 0, len(and.Exprs)-1)
+	for _, e := range and.Exprs {
+		if e != filter.Expr(fuzzy) {
+			rest = append(rest, e)
+		}
+	}
+	return fuzzy, filter.Join(rest...), true
+}
+
+// fuzzyTasks adalah GetAllTasks untuk dialek tanpa pg_trgm: sisa filter
+// dijalankan di database, kemiripan title dinilai di proses dengan
+// filter.WordSimilarity, lalu hasilnya diurutkan dan dipotong per halaman.
+func (r *taskRepository) fuzzyTasks(ctx context.Context, fuzzy filter.Fuzzy, rest filter.Expr, pagination Pagination) (*TaskPage, error) {
+	whereSQL, args, err := r.dialect.taskWhere(rest)
+	if err != nil {
+		return nil, err
+	}
+	query, args := r.dialect.Paginate("SELECT "+taskColumns+" FROM tasks"+whereSQL+" ORDER BY id", args, maxFuzzyCandidates+1, 0)
+
+	rows, err := r.q.QueryContext(ctx, r.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []models.Task
+	ranks := make(map[uint]float64)
+	scanned := 0
+	for rows.Next() {
+		if scanned++; scanned > maxFuzzyCandidates {
+			return nil, apperrors.Validation(fuzzyTooBroadCode, fmt.Sprintf("Fuzzy search can rank at most %d tasks, narrow the filter", maxFuzzyCandidates)).
+				WithParams(strconv.Itoa(maxFuzzyCandidates))
+		}
+		var task models.Task
+		if err := rows.Scan(taskDest(&task)...); err != nil {
+			return nil, err
+		}
+		if score := filter.WordSimilarity(fuzzy.Value, task.Title); score >= filter.FuzzyThreshold {
+			tasks = append(tasks, task)
+			ranks[task.ID] = score
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rank := func(task models.Task) float64 { return ranks[task.ID] }
+	compare := func(a, b models.Task) int {
+		return CompareTasks(a, b, pagination.Sort, rank)
+	}
+	slices.SortFunc(tasks, compare)
+
+	page := PageTasks(tasks, pagination, compare)
+	page.Hits = make(map[uint]SearchHit, len(page.Tasks))
+	for _, task := range page.Tasks {
+		page.Hits[task.ID] = SearchHit{Rank: ranks[task.ID]}
+	}
+	if err := r.loadRelated(ctx, page.Tasks); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// SuggestTitles mengusulkan title untuk type-ahead: pertama title yang
+// diawali prefix, lalu title yang salah satu katanya diawali prefix, lalu
+// title yang mirip (fuzzy) jika masih kurang dari limit.
+func (r *taskRepository) SuggestTitles(ctx context.Context, prefix string, limit int) (_ []TitleSuggestion, err error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.SuggestTitles", trace.WithAttributes(attribute.Int("suggest.limit", limit)))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	pattern := likeEscaper.Replace(strings.ToLower(prefix))
+	like := r.dialect.likeFold("title")
+	query := "SELECT id, title FROM tasks WHERE " + like + " OR " + like +
+		" ORDER BY CASE WHEN " + like + " THEN 0 ELSE 1 END, LENGTH(title), title, id"
+	query, args := r.dialect.Paginate(query, []interface{}{pattern + "%", "% " + pattern + "%", pattern + "%"}, limit, 0)
+
+	rows, err := r.q.QueryContext(ctx, r.dialect.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var suggestions []TitleSuggestion
+	seen := make(map[uint]bool)
+	for rows.Next() {
+		s := TitleSuggestion{Score: 1}
+		if err := rows.Scan(&s.ID, &s.Title); err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, s)
+		seen[s.ID] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(suggestions) >= limit {
+		return suggestions, nil
+	}
+
+	// Awalan yang salah ketik tetap mendapat usulan dari kemiripan trigram,
+	// kecuali tabel terlalu besar untuk dinilai di proses
+	page, err := r.GetAllTasks(ctx, filter.Fuzzy{Value: prefix}, Pagination{
+		Page:      1,
+		Limit:     limit,
+		Sort:      []SortField{{Field: RelevanceSort, Desc: true}},
+		SkipCount: true,
+	})
+	if isFuzzyTooBroad(err) {
+		return suggestions, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range page.Tasks {
+		if len(suggestions) == limit {
+			break
+		}
+		if !seen[task.ID] {
+			suggestions = append(suggestions, TitleSuggestion{ID: task.ID, Title: task.Title, Score: page.Hits[task.ID].Rank})
+		}
+	}
+	return suggestions, nil
+}
+
+func isFuzzyTooBroad(err error) bool {
+	var appErr *apperrors.Error
+	return errors.As(err, &appErr) && appErr.Code == fuzzyTooBroadCode
+}
@@ -0,0 +1,88 @@
+// repositories/idempotency_store.go
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// IdempotencyRecord adalah request yang pernah diproses dengan suatu
+// Idempotency-Key. Selama Completed false request pertama masih berjalan.
+type IdempotencyRecord struct {
+	Fingerprint string      `json:"fingerprint"`
+	Completed   bool        `json:"completed"`
+	Status      int         `json:"status,omitempty"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        []byte      `json:"body,omitempty"`
+}
+
+// IdempotencyStore menyimpan hasil request per Idempotency-Key.
+type IdempotencyStore interface {
+	// Reserve menandai key sedang diproses selama ttl jika key belum ada.
+	// Jika key sudah ada, record yang tersimpan dikembalikan dengan
+	// reserved false.
+	Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (existing *IdempotencyRecord, reserved bool, err error)
+	// Complete menyimpan respons akhir agar bisa diputar ulang selama ttl.
+	Complete(ctx context.Context, key string, record IdempotencyRecord, ttl time.Duration) error
+	// Release menghapus key sehingga request yang sama boleh dicoba lagi.
+	Release(ctx context.Context, key string) error
+}
+
+type redisIdempotencyStore struct {
+	redisClient *redis.Client
+}
+
+func NewIdempotencyStore(redisClient *redis.Client) IdempotencyStore {
+	return &redisIdempotencyStore{redisClient: redisClient}
+}
+
+func (s *redisIdempotencyStore) Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (*IdempotencyRecord, bool, error) {
+	pending, err := json.Marshal(IdempotencyRecord{Fingerprint: fingerprint})
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Key bisa kedaluwarsa di antara SETNX dan GET; coba sekali lagi
+	for attempt := 0; attempt < 2; attempt++ {
+		reserved, err := s.redisClient.SetNX(ctx, key, pending, ttl).Result()
+		if err != nil {
+			return nil, false, err
+		}
+		if reserved {
+			return nil, true, nil
+		}
+
+		data, err := s.redisClient.Get(ctx, key).Bytes()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+
+		var existing IdempotencyRecord
+		if err := json.Unmarshal(data, &existing); err != nil {
+			return nil, false, err
+		}
+		return &existing, false, nil
+	}
+	return nil, false, errors.New("idempotency key expired while reserving")
+}
+
+func (s *redisIdempotencyStore) Complete(ctx context.Context, key string, record IdempotencyRecord, ttl time.Duration) error {
+	record.Completed = true
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return s.redisClient.Set(ctx, key, data, ttl).Err()
+}
+
+func (s *redisIdempotencyStore) Release(ctx context.Context, key string) error {
+	return s.redisClient.Del(ctx, key).Err()
+}
@@ -0,0 +1,83 @@
+// repositories/in_memory.go
+package repositories
+
+import (
+	"cmp"
+	"strings"
+	"time"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+)
+
+// CompareTasks membandingkan dua task menurut sort dengan id sebagai
+// penentu terakhir, sama seperti ORDER BY dari orderBy. rank memberi nilai
+// RelevanceSort; nil jika tidak ada pencarian.
+func CompareTasks(a, b models.Task, sort []SortField, rank func(models.Task) float64) int {
+	for _, s := range sort {
+		var c int
+		if s.Field == RelevanceSort && rank != nil {
+			c = cmp.Compare(rank(a), rank(b))
+		} else {
+			c = compareSortValue(sortValue(a, s.Field), sortValue(b, s.Field))
+		}
+		if s.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(a.ID, b.ID)
+}
+
+func compareSortValue(a, b interface{}) int {
+	switch a := a.(type) {
+	case time.Time:
+		return a.Compare(b.(time.Time))
+	case string:
+		return strings.Compare(a, b.(string))
+	case int:
+		return cmp.Compare(a, b.(int))
+	}
+	return 0
+}
+
+// PageTasks mengambil satu halaman dari tasks yang sudah diurutkan dengan
+// compare, untuk mode halaman maupun keyset seperti GetAllTasks. Total
+// diisi jumlah tasks kecuali pagination.SkipCount.
+func PageTasks(tasks []models.Task, pagination Pagination, compare func(a, b models.Task) int) *TaskPage {
+	page := &TaskPage{Total: int64(len(tasks))}
+	if pagination.SkipCount {
+		page.Total = -1
+	}
+
+	switch {
+	case pagination.After != nil:
+		start := len(tasks)
+		for i, task := range tasks {
+			if compare(task, *pagination.After) > 0 {
+				start = i
+				break
+			}
+		}
+		tasks = tasks[start:]
+		page.HasPrev, page.HasNext = true, len(tasks) > pagination.Limit
+		page.Tasks = tasks[:min(pagination.Limit, len(tasks))]
+	case pagination.Before != nil:
+		end := 0
+		for i, task := range tasks {
+			if compare(task, *pagination.Before) < 0 {
+				end = i + 1
+			}
+		}
+		tasks = tasks[:end]
+		page.HasPrev, page.HasNext = len(tasks) > pagination.Limit, true
+		page.Tasks = tasks[max(0, len(tasks)-pagination.Limit):]
+	default:
+		offset := min((pagination.Page-1)*pagination.Limit, len(tasks))
+		end := min(offset+pagination.Limit, len(tasks))
+		page.HasPrev, page.HasNext = offset > 0, end < len(tasks)
+		page.Tasks = tasks[offset:end]
+	}
+	return page
+}
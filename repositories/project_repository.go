@@ -0,0 +1,342 @@
+// repositories/project_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ProjectSummary adalah project beserta jumlah task-nya per status.
+type ProjectSummary struct {
+	models.Project
+	Pending   int
+	Completed int
+}
+
+// ProjectRepository mengelola project dan keanggotaan task di dalamnya.
+// Flag archived project disalin ke setiap task-nya (models.Task.Archived),
+// sehingga mengarsipkan project menaikkan versi task yang terdampak dan
+// membuang cache-nya setelah commit.
+type ProjectRepository interface {
+	// ListProjects mengembalikan project urut nama; archived nil berarti
+	// semua project.
+	ListProjects(ctx context.Context, archived *bool) ([]ProjectSummary, error)
+	GetProject(ctx context.Context, id uint) (*ProjectSummary, error)
+	// CreateProject menyimpan project lalu mengisi ID, CreatedAt dan
+	// UpdatedAt. Nama project harus unik per owner.
+	CreateProject(ctx context.Context, project *models.Project) error
+	// UpdateProject mengganti nama dan warna project.
+	UpdateProject(ctx context.Context, project *models.Project) error
+	// SetArchived mengarsipkan project (atau sebaliknya) beserta task-nya.
+	SetArchived(ctx context.Context, id uint, archived bool) error
+	// DeleteProject hanya menghapus project yang tidak punya task.
+	DeleteProject(ctx context.Context, id uint) error
+	// MoveTask memindahkan task ke project projectID (nil berarti keluar
+	// dari project) lalu mengembalikan task terbaru. expectedVersion > 0
+	// berarti hanya jika versi task masih sama.
+	MoveTask(ctx context.Context, taskID uint, expectedVersion uint, projectID *uint) (*models.Task, error)
+}
+
+type projectRepository struct {
+	tasks *taskRepository
+}
+
+// NewProjectRepository membuat ProjectRepository di atas tasks agar
+// perubahan project memakai cache dan transaksi yang sama dengan task.
+func NewProjectRepository(tasks TaskStore) ProjectRepository {
+	return &projectRepository{tasks: tasks.store()}
+}
+
+// projectSummaryQuery menghitung task per status lewat LEFT JOIN agar
+// project tanpa task tetap muncul.
+const projectSummaryQuery = `SELECT p.id, p.owner, p.name, p.color, p.archived, p.created_at, p.updated_at,
+	COALESCE(SUM(CASE WHEN t.status = 'pending' THEN 1 ELSE 0 END), 0),
+	COALESCE(SUM(CASE WHEN t.status = 'completed' THEN 1 ELSE 0 END), 0)
+	FROM projects p LEFT JOIN tasks t ON t.project_id = p.id`
+
+const projectGroupBy = " GROUP BY p.id, p.owner, p.name, p.color, p.archived, p.created_at, p.updated_at"
+
+func scanProjectSummary(row rowScanner) (ProjectSummary, error) {
+	var p ProjectSummary
+	// Oracle menyimpan string kosong sebagai NULL
+	var color sql.NullString
+	err := row.Scan(&p.ID, &p.Owner, &p.Name, &color, &p.Archived, &p.CreatedAt, &p.UpdatedAt, &p.Pending, &p.Completed)
+	p.Color = color.String
+	return p, err
+}
+
+func (r *projectRepository) ListProjects(ctx context.Context, archived *bool) (_ []ProjectSummary, err error) {
+	ctx, span := tracer.Start(ctx, "ProjectRepository.ListProjects")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	query := projectSummaryQuery
+	var args []interface{}
+	if archived != nil {
+		query += " WHERE p.archived = ?"
+		args = append(args, boolInt(*archived))
+	}
+	rows, err := r.tasks.q.QueryContext(ctx, r.tasks.dialect.Rebind(query+projectGroupBy+" ORDER BY p.name, p.id"), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	projects := []ProjectSummary{}
+	for rows.Next() {
+		project, err := scanProjectSummary(rows)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+	return projects, rows.Err()
+}
+
+func (r *projectRepository) GetProject(ctx context.Context, id uint) (_ *ProjectSummary, err error) {
+	ctx, span := tracer.Start(ctx, "ProjectRepository.GetProject", trace.WithAttributes(attribute.Int("project.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	row := r.tasks.q.QueryRowContext(ctx, r.tasks.dialect.Rebind(projectSummaryQuery+" WHERE p.id = ?"+projectGroupBy), id)
+	project, err := scanProjectSummary(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, projectNotFound(id)
+		}
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (r *projectRepository) CreateProject(ctx context.Context, project *models.Project) (err error) {
+	ctx, span := tracer.Start(ctx, "ProjectRepository.CreateProject")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return r.tasks.transaction(ctx, func(tx *taskRepository) error {
+		if err := requireUniqueProjectName(ctx, tx, project); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		query := "INSERT INTO projects (owner, name, color, archived, created_at, updated_at) VALUES (?, ?, ?, 0, ?, ?)"
+		args := []interface{}{project.Owner, project.Name, project.Color, now, now}
+
+		var id int64
+		var err error
+		switch tx.dialect {
+		case Oracle:
+			query += " RETURNING id INTO ?"
+			_, err = tx.q.ExecContext(ctx, tx.dialect.Rebind(query), append(args, sql.Out{Dest: &id})...)
+		default:
+			query += " RETURNING id"
+			err = tx.q.QueryRowContext(ctx, tx.dialect.Rebind(query), args...).Scan(&id)
+		}
+		if err != nil {
+			return err
+		}
+
+		project.ID = uint(id)
+		project.Archived = false
+		project.CreatedAt, project.UpdatedAt = now, now
+		span.SetAttributes(attribute.Int("project.id", int(project.ID)))
+		return nil
+	})
+}
+
+func (r *projectRepository) UpdateProject(ctx context.Context, project *models.Project) (err error) {
+	ctx, span := tracer.Start(ctx, "ProjectRepository.UpdateProject", trace.WithAttributes(attribute.Int("project.id", int(project.ID))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return r.tasks.transaction(ctx, func(tx *taskRepository) error {
+		if err := requireUniqueProjectName(ctx, tx, project); err != nil {
+			return err
+		}
+		result, err := tx.q.ExecContext(ctx, tx.dialect.Rebind("UPDATE projects SET name = ?, color = ?, updated_at = ? WHERE id = ?"),
+			project.Name, project.Color, time.Now(), project.ID)
+		if err != nil {
+			return err
+		}
+		return requireProjectAffected(result, project.ID)
+	})
+}
+
+func (r *projectRepository) SetArchived(ctx context.Context, id uint, archived bool) (err error) {
+	ctx, span := tracer.Start(ctx, "ProjectRepository.SetArchived", trace.WithAttributes(
+		attribute.Int("project.id", int(id)),
+		attribute.Bool("project.archived", archived),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	flag := boolInt(archived)
+	return r.tasks.transaction(ctx, func(tx *taskRepository) error {
+		result, err := tx.q.ExecContext(ctx, tx.dialect.Rebind("UPDATE projects SET archived = ?, updated_at = ? WHERE id = ?"), flag, time.Now(), id)
+		if err != nil {
+			return err
+		}
+		if err := requireProjectAffected(result, id); err != nil {
+			return err
+		}
+
+		// Hanya task yang flag-nya berubah yang dinaikkan versinya;
+		// updated_at tidak diubah karena task itu sendiri tidak diedit
+		rows, err := tx.q.QueryContext(ctx, tx.dialect.Rebind("SELECT id FROM tasks WHERE project_id = ? AND archived <> ?"), id, flag)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		var taskIDs []uint
+		for rows.Next() {
+			var taskID uint
+			if err := rows.Scan(&taskID); err != nil {
+				return err
+			}
+			taskIDs = append(taskIDs, taskID)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		rows.Close()
+
+		if len(taskIDs) == 0 {
+			return nil
+		}
+		if _, err := tx.q.ExecContext(ctx, tx.dialect.Rebind("UPDATE tasks SET archived = ?, version = version + 1 WHERE project_id = ? AND archived <> ?"), flag, id, flag); err != nil {
+			return err
+		}
+		for _, taskID := range taskIDs {
+			tx.invalidateCache(ctx, taskID)
+		}
+		return nil
+	})
+}
+
+func (r *projectRepository) DeleteProject(ctx context.Context, id uint) (err error) {
+	ctx, span := tracer.Start(ctx, "ProjectRepository.DeleteProject", trace.WithAttributes(attribute.Int("project.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return r.tasks.transaction(ctx, func(tx *taskRepository) error {
+		var count int
+		if err := tx.q.QueryRowContext(ctx, tx.dialect.Rebind("SELECT COUNT(*) FROM tasks WHERE project_id = ?"), id).Scan(&count); err != nil {
+			return err
+		}
+		if count > 0 {
+			return apperrors.Conflict("project_not_empty", fmt.Sprintf("Project %d still has %d tasks", id, count)).
+				WithParams(strconv.FormatUint(uint64(id), 10), strconv.Itoa(count))
+		}
+		result, err := tx.q.ExecContext(ctx, tx.dialect.Rebind("DELETE FROM projects WHERE id = ?"), id)
+		if err != nil {
+			return err
+		}
+		return requireProjectAffected(result, id)
+	})
+}
+
+func (r *projectRepository) MoveTask(ctx context.Context, taskID uint, expectedVersion uint, projectID *uint) (_ *models.Task, err error) {
+	ctx, span := tracer.Start(ctx, "ProjectRepository.MoveTask", trace.WithAttributes(attribute.Int("task.id", int(taskID))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	var task *models.Task
+	err = r.tasks.transaction(ctx, func(tx *taskRepository) error {
+		current, err := lockTask(ctx, tx, taskID, expectedVersion)
+		if err != nil {
+			return err
+		}
+		if projectID != nil {
+			if err := requireOpenProject(ctx, tx, *projectID); err != nil {
+				return err
+			}
+		}
+		if sameProject(current.ProjectID, projectID) {
+			task = current
+			return nil
+		}
+
+		// Task yang keluar dari project terarsip ikut tampil kembali
+		query := "UPDATE tasks SET project_id = ?, archived = 0, version = version + 1, updated_at = ? WHERE id = ?"
+		if _, err := tx.q.ExecContext(ctx, tx.dialect.Rebind(query), projectArg(projectID), time.Now(), taskID); err != nil {
+			return err
+		}
+		tx.invalidateCache(ctx, taskID)
+		task, err = tx.selectTask(ctx, taskID, "")
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// requireOpenProject memastikan project ada dan tidak diarsipkan, sehingga
+// task bisa ditambahkan ke dalamnya. Baris project dikunci sampai transaksi
+// selesai agar SetArchived tidak bisa menyela di antara pemeriksaan dan
+// penulisan task.
+func requireOpenProject(ctx context.Context, r *taskRepository, id uint) error {
+	var archived bool
+	err := r.q.QueryRowContext(ctx, r.dialect.Rebind("SELECT archived FROM projects WHERE id = ?"+r.dialect.ForUpdate()), id).Scan(&archived)
+	if errors.Is(err, sql.ErrNoRows) {
+		return projectNotFound(id)
+	}
+	if err != nil {
+		return err
+	}
+	if archived {
+		return apperrors.Conflict("project_archived", fmt.Sprintf("Project %d is archived", id)).WithParams(strconv.FormatUint(uint64(id), 10))
+	}
+	return nil
+}
+
+// requireUniqueProjectName menolak nama yang sudah dipakai project lain
+// milik owner yang sama sebelum constraint UNIQUE gagal dengan error khas
+// driver.
+func requireUniqueProjectName(ctx context.Context, tx *taskRepository, project *models.Project) error {
+	var count int
+	err := tx.q.QueryRowContext(ctx, tx.dialect.Rebind("SELECT COUNT(*) FROM projects WHERE owner = ? AND name = ? AND id <> ?"),
+		project.Owner, project.Name, project.ID).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return apperrors.Conflict("project_name_taken", fmt.Sprintf("A project named %q already exists", project.Name)).WithParams(project.Name)
+	}
+	return nil
+}
+
+func requireProjectAffected(result sql.Result, id uint) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return projectNotFound(id)
+	}
+	return nil
+}
+
+func sameProject(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// projectArg mengubah project ID opsional menjadi argumen query (NULL jika
+// nil).
+func projectArg(id *uint) interface{} {
+	if id == nil {
+		return nil
+	}
+	return int64(*id)
+}
+
+func projectNotFound(id uint) error {
+	return apperrors.NotFound("project_not_found", fmt.Sprintf("Project %d not found", id)).WithParams(strconv.FormatUint(uint64(id), 10))
+}
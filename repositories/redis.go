@@ -2,16 +2,21 @@
 package repositories
 
 import (
+	"github.com/go-redis/redis/extra/redisotel/v8"
 	"github.com/go-redis/redis/v8"
 	"github.com/programmercintasunnah/go-todolist-ilcs/config"
 )
 
-func InitRedis(cfg config.Config) *redis.Client {
+func InitRedis(cfg config.RedisConfig) *redis.Client {
 	rdb := redis.NewClient(&redis.Options{
-		Addr:     cfg.RedisAddr,
-		Password: cfg.RedisPassword,
-		DB:       cfg.RedisDB,
+		Addr:     cfg.Addr,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+		PoolSize: cfg.PoolSize,
 	})
 
+	// Satu span per perintah Redis
+	rdb.AddHook(redisotel.NewTracingHook())
+
 	return rdb
 }
@@ -0,0 +1,133 @@
+// repositories/sort.go
+package repositories
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+)
+
+// SortField adalah satu kunci pengurutan, mis. {Field: "due_date", Desc: true}.
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// sortableFields adalah field yang boleh dipakai untuk mengurutkan. Nama
+// kolomnya sama dengan nama field dan selalu diambil dari daftar ini, bukan
+// dari input, sehingga ORDER BY aman dari SQL injection.
+var sortableFields = []string{"due_date", "created_at", "updated_at", "title", "status", "priority"}
+
+// SortableFields mengembalikan nama field yang didukung oleh GetAllTasks.
+func SortableFields() []string {
+	return slices.Clone(sortableFields)
+}
+
+// sortColumn mengembalikan kolom untuk field sort.
+func sortColumn(field string) (string, bool) {
+	i := slices.Index(sortableFields, field)
+	if i < 0 {
+		return "", false
+	}
+	return sortableFields[i], true
+}
+
+// orderBy menyusun klausa ORDER BY. id selalu ditambahkan di akhir agar
+// urutan stabil untuk nilai yang sama, sehingga pagination dengan OFFSET
+// tidak melompati atau mengulang baris. reverse membalik semua arah, untuk
+// membaca halaman sebelum cursor. ranked berarti query memilih kolom
+// search_rank sehingga RelevanceSort boleh dipakai.
+func orderBy(sort []SortField, reverse, ranked bool) (string, error) {
+	direction := func(desc bool) string {
+		if desc != reverse {
+			return " DESC"
+		}
+		return " ASC"
+	}
+
+	terms := make([]string, 0, len(sort)+1)
+	for _, s := range sort {
+		column, ok := sortColumn(s.Field)
+		if s.Field == RelevanceSort && ranked {
+			column, ok = "search_rank", true
+		}
+		if !ok {
+			return "", fmt.Errorf("unsupported sort field %q", s.Field)
+		}
+		terms = append(terms, column+direction(s.Desc))
+	}
+	terms = append(terms, "id"+direction(false))
+	return " ORDER BY " + strings.Join(terms, ", "), nil
+}
+
+// sortValue mengembalikan nilai field sort dari task.
+func sortValue(task models.Task, field string) interface{} {
+	switch field {
+	case "due_date":
+		return task.DueDate
+	case "created_at":
+		return task.CreatedAt
+	case "updated_at":
+		return task.UpdatedAt
+	case "title":
+		return task.Title
+	case "status":
+		return task.Status
+	case "priority":
+		return task.Priority
+	}
+	return nil
+}
+
+// SortKey mengembalikan nilai kunci sort task (termasuk id) dengan nama
+// field JSON-nya, untuk disimpan di cursor. Hasilnya bisa di-unmarshal
+// kembali menjadi models.Task untuk Pagination.After atau Before.
+func SortKey(task models.Task, sort []SortField) map[string]interface{} {
+	key := map[string]interface{}{"id": task.ID}
+	for _, s := range sort {
+		key[s.Field] = sortValue(task, s.Field)
+	}
+	return key
+}
+
+// keysetWhere menyusun kondisi "baris sesudah boundary" menurut sort (atau
+// sebelum jika backward) sebagai rangkaian OR, mis. untuk sort a, b:
+// (a > ?) OR (a = ? AND b > ?) OR (a = ? AND b = ? AND id > ?). Bentuk ini
+// dipakai karena perbandingan tuple tidak mendukung arah campuran dan tidak
+// tersedia di semua dialek.
+func keysetWhere(sort []SortField, boundary models.Task, backward bool) (string, []interface{}, error) {
+	type key struct {
+		column string
+		desc   bool
+		value  interface{}
+	}
+	keys := make([]key, 0, len(sort)+1)
+	for _, s := range sort {
+		column, ok := sortColumn(s.Field)
+		if !ok {
+			return "", nil, fmt.Errorf("unsupported sort field %q", s.Field)
+		}
+		keys = append(keys, key{column: column, desc: s.Desc, value: sortValue(boundary, s.Field)})
+	}
+	keys = append(keys, key{column: "id", value: boundary.ID})
+
+	var terms []string
+	var args []interface{}
+	for i, k := range keys {
+		var parts []string
+		for _, prev := range keys[:i] {
+			parts = append(parts, prev.column+" = ?")
+			args = append(args, prev.value)
+		}
+		op := " > ?"
+		if k.desc != backward {
+			op = " < ?"
+		}
+		parts = append(parts, k.column+op)
+		args = append(args, k.value)
+		terms = append(terms, "("+strings.Join(parts, " AND ")+")")
+	}
+	return " AND (" + strings.Join(terms, " OR ") + ")", args, nil
+}
@@ -0,0 +1,167 @@
+// repositories/subtask.go
+package repositories
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func (r *taskRepository) Subtasks(ctx context.Context, parentID uint) (_ []models.Task, err error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.Subtasks", trace.WithAttributes(attribute.Int("task.id", int(parentID))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	rows, err := r.q.QueryContext(ctx, r.dialect.Rebind("SELECT "+taskColumns+" FROM tasks WHERE parent_id = ? ORDER BY id"), parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := []models.Task{}
+	for rows.Next() {
+		var task models.Task
+		if err := rows.Scan(taskDest(&task)...); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := r.loadRelated(ctx, tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (r *taskRepository) SubtreeHeight(ctx context.Context, id uint, limit int) (_ int, err error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.SubtreeHeight", trace.WithAttributes(attribute.Int("task.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	// Satu query IN per tingkat, seperti walkDependencies
+	height := 0
+	for level := []uint{id}; height <= limit; height++ {
+		var next []uint
+		for start := 0; start < len(level); start += maxInListSize {
+			chunk := level[start:min(start+maxInListSize, len(level))]
+			ids, err := queryIDs(ctx, r, subtaskIDsQuery, chunk)
+			if err != nil {
+				return 0, err
+			}
+			next = append(next, ids...)
+		}
+		if len(next) == 0 {
+			break
+		}
+		level = next
+	}
+	return height, nil
+}
+
+func (r *taskRepository) ParentIDs(ctx context.Context, ids []uint) (_ []uint, err error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.ParentIDs", trace.WithAttributes(attribute.Int("task.count", len(ids))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	var parents []uint
+	for start := 0; start < len(ids); start += maxInListSize {
+		chunk := ids[start:min(start+maxInListSize, len(ids))]
+		found, err := queryIDs(ctx, r, "SELECT DISTINCT parent_id FROM tasks WHERE parent_id IS NOT NULL AND id IN ", chunk)
+		if err != nil {
+			return nil, err
+		}
+		parents = append(parents, found...)
+	}
+	slices.Sort(parents)
+	return slices.Compact(parents), nil
+}
+
+func (r *taskRepository) SetParent(ctx context.Context, id uint, expectedVersion uint, parentID *uint) (err error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.SetParent", trace.WithAttributes(attribute.Int("task.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	query := "UPDATE tasks SET parent_id = ?, updated_at = ?, version = version + 1 WHERE id = ?"
+	args := []interface{}{parentArg(parentID), time.Now(), id}
+	if expectedVersion > 0 {
+		query += " AND version = ?"
+		args = append(args, expectedVersion)
+	}
+
+	result, err := r.q.ExecContext(ctx, r.dialect.Rebind(query), args...)
+	if err != nil {
+		return err
+	}
+	if err := r.requireAffected(ctx, result, id, expectedVersion); err != nil {
+		return err
+	}
+	r.invalidateCache(ctx, id)
+	return nil
+}
+
+// parentArg mengubah parent ID opsional menjadi argumen query (NULL jika
+// nil).
+func parentArg(id *uint) interface{} {
+	if id == nil {
+		return nil
+	}
+	return int64(*id)
+}
+
+// detachSubtasks menjadikan subtask dari ids task tingkat atas sebelum ids
+// dihapus, menaikkan versinya dan membuang cache-nya. Subtask yang ikut
+// terhapus tidak masalah ikut diubah.
+func detachSubtasks(ctx context.Context, tx *taskRepository, ids []uint) error {
+	in := " IN (" + strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ") + ")"
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	subtasks, err := queryIDs(ctx, tx, subtaskIDsQuery, ids)
+	if err != nil {
+		return err
+	}
+	if len(subtasks) == 0 {
+		return nil
+	}
+	if _, err := tx.q.ExecContext(ctx, tx.dialect.Rebind("UPDATE tasks SET parent_id = NULL, version = version + 1 WHERE parent_id"+in), args...); err != nil {
+		return err
+	}
+	for _, id := range subtasks {
+		tx.invalidateCache(ctx, id)
+	}
+	return nil
+}
+
+const subtaskIDsQuery = "SELECT id FROM tasks WHERE parent_id IN "
+
+// queryIDs menjalankan query yang diakhiri "IN " dengan daftar ids dan
+// mengembalikan kolom ID pertama dari setiap baris.
+func queryIDs(ctx context.Context, r *taskRepository, query string, ids []uint) ([]uint, error) {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	rows, err := r.q.QueryContext(ctx, r.dialect.Rebind(query+"("+strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")+")"), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var found []uint
+	for rows.Next() {
+		var id uint
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		found = append(found, id)
+	}
+	return found, rows.Err()
+}
@@ -0,0 +1,458 @@
+// repositories/tag_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TagUsage adalah tag beserta jumlah task yang memakainya.
+type TagUsage struct {
+	models.Tag
+	TaskCount int
+}
+
+// TagRepository mengelola tag dan pemasangannya ke task. Tag termasuk
+// representasi task, sehingga setiap perubahan menaikkan versi task yang
+// terdampak (ETag ikut berubah) dan membuang cache-nya setelah commit.
+type TagRepository interface {
+	// ListTags mengembalikan semua tag urut nama.
+	ListTags(ctx context.Context) ([]TagUsage, error)
+	GetTag(ctx context.Context, id uint) (*TagUsage, error)
+	// CreateTag menyimpan tag lalu mengisi ID. Nama harus unik.
+	CreateTag(ctx context.Context, tag *models.Tag) error
+	// RenameTag mengganti nama tag tag.ID menjadi tag.Name.
+	RenameTag(ctx context.Context, tag *models.Tag) error
+	// MergeTags memindahkan task dari tag sourceID ke targetID lalu
+	// menghapus tag sourceID.
+	MergeTags(ctx context.Context, sourceID, targetID uint) error
+	DeleteTag(ctx context.Context, id uint) error
+	// AttachTags memasang tag bernama names ke task, membuat tag yang belum
+	// ada, lalu mengembalikan task terbaru. expectedVersion > 0 berarti
+	// hanya jika versi task masih sama.
+	AttachTags(ctx context.Context, taskID uint, expectedVersion uint, names []string) (*models.Task, error)
+	// DetachTag melepas tag tagID dari task; lihat AttachTags.
+	DetachTag(ctx context.Context, taskID uint, expectedVersion uint, tagID uint) (*models.Task, error)
+}
+
+type tagRepository struct {
+	tasks *taskRepository
+}
+
+// NewTagRepository membuat TagRepository di atas tasks agar perubahan tag
+// memakai cache dan transaksi yang sama dengan task.
+func NewTagRepository(tasks TaskStore) TagRepository {
+	return &tagRepository{tasks: tasks.store()}
+}
+
+// tagUsageQuery menghitung task lewat JOIN tasks agar baris task_tags milik
+// task yang terhapus tanpa cascade (SQLite tanpa PRAGMA foreign_keys) tidak
+// ikut terhitung.
+const tagUsageQuery = "SELECT tg.id, tg.name, COUNT(t.id) FROM tags tg LEFT JOIN task_tags tt ON tt.tag_id = tg.id LEFT JOIN tasks t ON t.id = tt.task_id"
+
+func (r *tagRepository) ListTags(ctx context.Context) (_ []TagUsage, err error) {
+	ctx, span := tracer.Start(ctx, "TagRepository.ListTags")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	rows, err := r.tasks.q.QueryContext(ctx, tagUsageQuery+" GROUP BY tg.id, tg.name ORDER BY tg.name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []TagUsage{}
+	for rows.Next() {
+		var tag TagUsage
+		if err := rows.Scan(&tag.ID, &tag.Name, &tag.TaskCount); err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+	return tags, rows.Err()
+}
+
+func (r *tagRepository) GetTag(ctx context.Context, id uint) (_ *TagUsage, err error) {
+	ctx, span := tracer.Start(ctx, "TagRepository.GetTag", trace.WithAttributes(attribute.Int("tag.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	var tag TagUsage
+	row := r.tasks.q.QueryRowContext(ctx, r.tasks.dialect.Rebind(tagUsageQuery+" WHERE tg.id = ? GROUP BY tg.id, tg.name"), id)
+	if err := row.Scan(&tag.ID, &tag.Name, &tag.TaskCount); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, tagNotFound(id)
+		}
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *tagRepository) CreateTag(ctx context.Context, tag *models.Tag) (err error) {
+	ctx, span := tracer.Start(ctx, "TagRepository.CreateTag")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return r.tasks.transaction(ctx, func(tx *taskRepository) error {
+		id, inserted, err := insertTag(ctx, tx, tag.Name)
+		if err != nil {
+			return err
+		}
+		if !inserted {
+			return tagNameTaken(tag.Name)
+		}
+		tag.ID = id
+		span.SetAttributes(attribute.Int("tag.id", int(id)))
+		return nil
+	})
+}
+
+func (r *tagRepository) RenameTag(ctx context.Context, tag *models.Tag) (err error) {
+	ctx, span := tracer.Start(ctx, "TagRepository.RenameTag", trace.WithAttributes(attribute.Int("tag.id", int(tag.ID))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return r.tasks.transaction(ctx, func(tx *taskRepository) error {
+		if err := requireUniqueTagName(ctx, tx, tag); err != nil {
+			return err
+		}
+		result, err := tx.q.ExecContext(ctx, tx.dialect.Rebind("UPDATE tags SET name = ? WHERE id = ?"), tag.Name, tag.ID)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return tagNotFound(tag.ID)
+		}
+		return touchTagged(ctx, tx, tag.ID)
+	})
+}
+
+func (r *tagRepository) MergeTags(ctx context.Context, sourceID, targetID uint) (err error) {
+	ctx, span := tracer.Start(ctx, "TagRepository.MergeTags", trace.WithAttributes(
+		attribute.Int("tag.source_id", int(sourceID)),
+		attribute.Int("tag.target_id", int(targetID)),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return r.tasks.transaction(ctx, func(tx *taskRepository) error {
+		for _, id := range []uint{sourceID, targetID} {
+			if err := requireTag(ctx, tx, id); err != nil {
+				return err
+			}
+		}
+		// Versi dinaikkan sebelum baris sumber dipindahkan
+		if err := touchTagged(ctx, tx, sourceID); err != nil {
+			return err
+		}
+
+		statements := []struct {
+			query string
+			args  []interface{}
+		}{
+			{"INSERT INTO task_tags (task_id, tag_id) SELECT task_id, ? FROM task_tags WHERE tag_id = ? AND task_id NOT IN (SELECT task_id FROM task_tags WHERE tag_id = ?)",
+				[]interface{}{targetID, sourceID, targetID}},
+			{"DELETE FROM task_tags WHERE tag_id = ?", []interface{}{sourceID}},
+			{"DELETE FROM tags WHERE id = ?", []interface{}{sourceID}},
+		}
+		for _, s := range statements {
+			if _, err := tx.q.ExecContext(ctx, tx.dialect.Rebind(s.query), s.args...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *tagRepository) DeleteTag(ctx context.Context, id uint) (err error) {
+	ctx, span := tracer.Start(ctx, "TagRepository.DeleteTag", trace.WithAttributes(attribute.Int("tag.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return r.tasks.transaction(ctx, func(tx *taskRepository) error {
+		if err := touchTagged(ctx, tx, id); err != nil {
+			return err
+		}
+		// Baris task_tags dihapus eksplisit karena SQLite hanya menjalankan
+		// ON DELETE CASCADE jika PRAGMA foreign_keys aktif
+		if _, err := tx.q.ExecContext(ctx, tx.dialect.Rebind("DELETE FROM task_tags WHERE tag_id = ?"), id); err != nil {
+			return err
+		}
+		result, err := tx.q.ExecContext(ctx, tx.dialect.Rebind("DELETE FROM tags WHERE id = ?"), id)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return tagNotFound(id)
+		}
+		return nil
+	})
+}
+
+func (r *tagRepository) AttachTags(ctx context.Context, taskID uint, expectedVersion uint, names []string) (_ *models.Task, err error) {
+	ctx, span := tracer.Start(ctx, "TagRepository.AttachTags", trace.WithAttributes(attribute.Int("task.id", int(taskID))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	var task *models.Task
+	err = r.tasks.transaction(ctx, func(tx *taskRepository) error {
+		current, err := lockTask(ctx, tx, taskID, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		changed := false
+		for _, name := range names {
+			if hasTag(current, name) {
+				continue
+			}
+			var id uint
+			err := tx.q.QueryRowContext(ctx, tx.dialect.Rebind("SELECT id FROM tags WHERE name = ?"), name).Scan(&id)
+			if errors.Is(err, sql.ErrNoRows) {
+				id, _, err = insertTag(ctx, tx, name)
+			}
+			if err != nil {
+				return err
+			}
+			if _, err := tx.q.ExecContext(ctx, tx.dialect.Rebind("INSERT INTO task_tags (task_id, tag_id) VALUES (?, ?)"), taskID, id); err != nil {
+				return err
+			}
+			current.Tags = append(current.Tags, models.Tag{ID: id, Name: name})
+			changed = true
+		}
+
+		task, err = finishTaskChange(ctx, tx, current, changed)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (r *tagRepository) DetachTag(ctx context.Context, taskID uint, expectedVersion uint, tagID uint) (_ *models.Task, err error) {
+	ctx, span := tracer.Start(ctx, "TagRepository.DetachTag", trace.WithAttributes(
+		attribute.Int("task.id", int(taskID)),
+		attribute.Int("tag.id", int(tagID)),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	var task *models.Task
+	err = r.tasks.transaction(ctx, func(tx *taskRepository) error {
+		current, err := lockTask(ctx, tx, taskID, expectedVersion)
+		if err != nil {
+			return err
+		}
+		result, err := tx.q.ExecContext(ctx, tx.dialect.Rebind("DELETE FROM task_tags WHERE task_id = ? AND tag_id = ?"), taskID, tagID)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return taskTagNotFound(taskID, tagID)
+		}
+
+		task, err = finishTaskChange(ctx, tx, current, true)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// lockTask membaca dan mengunci task lalu memeriksa versinya seperti If-Match.
+func lockTask(ctx context.Context, tx *taskRepository, id uint, expectedVersion uint) (*models.Task, error) {
+	task, err := tx.selectTask(ctx, id, tx.dialect.ForUpdate())
+	if err != nil {
+		return nil, err
+	}
+	if expectedVersion > 0 && task.Version != expectedVersion {
+		return nil, VersionMismatch(id)
+	}
+	return task, nil
+}
+
+// finishTaskChange menaikkan versi dan updated_at task jika tag atau
+// checklist-nya berubah, lalu membaca ulang task.
+func finishTaskChange(ctx context.Context, tx *taskRepository, task *models.Task, changed bool) (*models.Task, error) {
+	if !changed {
+		return task, nil
+	}
+	if _, err := tx.q.ExecContext(ctx, tx.dialect.Rebind("UPDATE tasks SET version = version + 1, updated_at = ? WHERE id = ?"), time.Now(), task.ID); err != nil {
+		return nil, err
+	}
+	tx.invalidateCache(ctx, task.ID)
+	return tx.selectTask(ctx, task.ID, "")
+}
+
+// touchTagged menaikkan versi semua task yang memakai tag id dan membuang
+// cache-nya. updated_at tidak diubah karena task itu sendiri tidak diedit.
+func touchTagged(ctx context.Context, tx *taskRepository, id uint) error {
+	rows, err := tx.q.QueryContext(ctx, tx.dialect.Rebind("SELECT task_id FROM task_tags WHERE tag_id = ?"), id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	var taskIDs []uint
+	for rows.Next() {
+		var taskID uint
+		if err := rows.Scan(&taskID); err != nil {
+			return err
+		}
+		taskIDs = append(taskIDs, taskID)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	if len(taskIDs) == 0 {
+		return nil
+	}
+	if _, err := tx.q.ExecContext(ctx, tx.dialect.Rebind("UPDATE tasks SET version = version + 1 WHERE id IN (SELECT task_id FROM task_tags WHERE tag_id = ?)"), id); err != nil {
+		return err
+	}
+	for _, taskID := range taskIDs {
+		tx.invalidateCache(ctx, taskID)
+	}
+	return nil
+}
+
+// loadTags mengisi Tags semua tasks dengan satu query per maxInListSize
+// task, bukan satu query per task.
+func (r *taskRepository) loadTags(ctx context.Context, tasks []models.Task) error {
+	index := make(map[uint]int, len(tasks))
+	for i := range tasks {
+		tasks[i].Tags = []models.Tag{}
+		index[tasks[i].ID] = i
+	}
+
+	for start := 0; start < len(tasks); start += maxInListSize {
+		chunk := tasks[start:min(start+maxInListSize, len(tasks))]
+		args := make([]interface{}, len(chunk))
+		for i, task := range chunk {
+			args[i] = task.ID
+		}
+		query := "SELECT tt.task_id, tg.id, tg.name FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id WHERE tt.task_id IN (" +
+			strings.TrimSuffix(strings.Repeat("?, ", len(chunk)), ", ") + ") ORDER BY tg.name"
+		if err := r.scanTags(ctx, query, args, tasks, index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *taskRepository) scanTags(ctx context.Context, query string, args []interface{}, tasks []models.Task, index map[uint]int) error {
+	rows, err := r.q.QueryContext(ctx, r.dialect.Rebind(query), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var taskID uint
+		var tag models.Tag
+		if err := rows.Scan(&taskID, &tag.ID, &tag.Name); err != nil {
+			return err
+		}
+		i := index[taskID]
+		tasks[i].Tags = append(tasks[i].Tags, tag)
+	}
+	return rows.Err()
+}
+
+// insertTag membuat tag bernama name jika belum ada. Jika transaksi lain
+// sudah membuat nama yang sama (walau setelah SELECT pemanggil), ID tag itu
+// yang dikembalikan dengan inserted false, bukan error UNIQUE dari driver.
+func insertTag(ctx context.Context, tx *taskRepository, name string) (id uint, inserted bool, err error) {
+	now := time.Now()
+
+	var newID, created int64
+	switch tx.dialect {
+	case Oracle:
+		// Oracle tidak punya ON CONFLICT; DUP_VAL_ON_INDEX ditangkap di
+		// PL/SQL sehingga hanya INSERT yang gagal yang dibatalkan
+		query := `DECLARE
+				v_id tags.id%TYPE;
+				v_inserted NUMBER := 1;
+			BEGIN
+				BEGIN
+					INSERT INTO tags (name, created_at) VALUES (?, ?) RETURNING id INTO v_id;
+				EXCEPTION WHEN DUP_VAL_ON_INDEX THEN
+					SELECT id INTO v_id FROM tags WHERE name = ?;
+					v_inserted := 0;
+				END;
+				? := v_id;
+				? := v_inserted;
+			END;`
+		_, err = tx.q.ExecContext(ctx, tx.dialect.Rebind(query), name, now, name, sql.Out{Dest: &newID}, sql.Out{Dest: &created})
+	default:
+		query := "INSERT INTO tags (name, created_at) VALUES (?, ?) ON CONFLICT (name) DO NOTHING RETURNING id"
+		created = 1
+		err = tx.q.QueryRowContext(ctx, tx.dialect.Rebind(query), name, now).Scan(&newID)
+		if errors.Is(err, sql.ErrNoRows) {
+			created = 0
+			err = tx.q.QueryRowContext(ctx, tx.dialect.Rebind("SELECT id FROM tags WHERE name = ?"), name).Scan(&newID)
+		}
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return uint(newID), created == 1, nil
+}
+
+// requireUniqueTagName menolak nama yang sudah dipakai tag lain sebelum
+// constraint UNIQUE gagal dengan error khas driver.
+func requireUniqueTagName(ctx context.Context, tx *taskRepository, tag *models.Tag) error {
+	var count int
+	err := tx.q.QueryRowContext(ctx, tx.dialect.Rebind("SELECT COUNT(*) FROM tags WHERE name = ? AND id <> ?"), tag.Name, tag.ID).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return tagNameTaken(tag.Name)
+	}
+	return nil
+}
+
+func requireTag(ctx context.Context, tx *taskRepository, id uint) error {
+	var count int
+	if err := tx.q.QueryRowContext(ctx, tx.dialect.Rebind("SELECT COUNT(*) FROM tags WHERE id = ?"), id).Scan(&count); err != nil {
+		return err
+	}
+	if count == 0 {
+		return tagNotFound(id)
+	}
+	return nil
+}
+
+func hasTag(task *models.Task, name string) bool {
+	for _, tag := range task.Tags {
+		if tag.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func tagNotFound(id uint) error {
+	return apperrors.NotFound("tag_not_found", fmt.Sprintf("Tag %d not found", id)).WithParams(strconv.FormatUint(uint64(id), 10))
+}
+
+func tagNameTaken(name string) error {
+	return apperrors.Conflict("tag_name_taken", fmt.Sprintf("A tag named %q already exists", name)).WithParams(name)
+}
+
+func taskTagNotFound(taskID, tagID uint) error {
+	return apperrors.NotFound("task_tag_not_found", fmt.Sprintf("Task %d does not have tag %d", taskID, tagID)).
+		WithParams(strconv.FormatUint(uint64(taskID), 10), strconv.FormatUint(uint64(tagID), 10))
+}
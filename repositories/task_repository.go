@@ -6,48 +6,216 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/config"
+	"github.com/programmercintasunnah/go-todolist-ilcs/filter"
 	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/tracing"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("github.com/programmercintasunnah/go-todolist-ilcs/repositories")
+
+// Pagination mengatur halaman dan urutan hasil GetAllTasks. Sort kosong
+// berarti urut berdasarkan id.
 type Pagination struct {
 	Page  int
 	Limit int
+	Sort  []SortField
+	// After mengaktifkan keyset pagination: hanya task sesudah After menurut
+	// Sort, tanpa OFFSET (Page diabaikan). Before sama tetapi mundur ke
+	// halaman sebelumnya. Cukup field kunci sort dan ID yang diisi.
+	After  *models.Task
+	Before *models.Task
+	// SkipCount melewati COUNT(*); TaskPage.Total menjadi -1.
+	SkipCount bool
+}
+
+// TaskPage adalah satu halaman hasil GetAllTasks.
+type TaskPage struct {
+	Tasks []models.Task
+	// Total adalah jumlah semua task yang cocok dengan filter, atau -1 jika
+	// Pagination.SkipCount.
+	Total int64
+	// HasNext dan HasPrev melaporkan apakah masih ada task sesudah atau
+	// sebelum halaman ini.
+	HasNext bool
+	HasPrev bool
+	// Hits berisi relevansi dan cuplikan per ID task jika filter memuat
+	// filter.Search atau filter.Fuzzy (lihat filter.Ranking).
+	Hits map[uint]SearchHit
 }
 
 type TaskRepository interface {
-	CreateTask(task *models.Task) error
-	GetTaskByID(id uint) (*models.Task, error)
-	GetAllTasks(filter map[string]interface{}, pagination Pagination, search string) ([]models.Task, int64, error)
-	UpdateTask(task *models.Task) error
-	DeleteTask(id uint) error
+	CreateTask(ctx context.Context, task *models.Task) error
+	GetTaskByID(ctx context.Context, id uint) (*models.Task, error)
+	// GetAllTasks mengembalikan task yang cocok dengan where (nil berarti
+	// semua task).
+	GetAllTasks(ctx context.Context, where filter.Expr, pagination Pagination) (*TaskPage, error)
+	UpdateTask(ctx context.Context, task *models.Task) error
+	// DeleteTask menghapus task; expectedVersion > 0 berarti hanya hapus
+	// jika versinya masih sama.
+	DeleteTask(ctx context.Context, id uint, expectedVersion uint) error
+	// BulkWrite menjalankan ops dalam satu transaksi. Jika atomic, operasi
+	// pertama yang gagal membatalkan semuanya dan dikembalikan sebagai
+	// *BulkError; selain itu setiap operasi berdiri sendiri (savepoint) dan
+	// kegagalannya dicatat di BulkResult.Err. after boleh nil.
+	BulkWrite(ctx context.Context, ops []BulkOperation, atomic bool, after BulkHook) ([]BulkResult, error)
+	UpdateByFilter(ctx context.Context, where filter.Expr, changes TaskChanges, dryRun bool) ([]uint, error)
+	DeleteByFilter(ctx context.Context, where filter.Expr, dryRun bool) ([]uint, error)
+	// SuggestTitles mengembalikan paling banyak limit usulan title untuk
+	// prefix, diurutkan dari yang paling cocok.
+	SuggestTitles(ctx context.Context, prefix string, limit int) ([]TitleSuggestion, error)
+	// Subtasks mengembalikan subtask langsung dari parentID, urut id.
+	Subtasks(ctx context.Context, parentID uint) ([]models.Task, error)
+	// SubtreeHeight mengembalikan jumlah tingkat subtask di bawah task id,
+	// paling banyak limit+1 karena pohon yang lebih dalam pasti melebihi
+	// batas.
+	SubtreeHeight(ctx context.Context, id uint, limit int) (int, error)
+	// ParentIDs mengembalikan parent_id berbeda dari task ids, urut id.
+	ParentIDs(ctx context.Context, ids []uint) ([]uint, error)
+	// SetParent menjadikan task id subtask dari parentID (nil berarti task
+	// tingkat atas); lihat DeleteTask untuk expectedVersion. Kedalaman dan
+	// siklus diperiksa oleh pemanggil.
+	SetParent(ctx context.Context, id uint, expectedVersion uint, parentID *uint) error
+}
+
+// dbtx adalah bagian *sql.DB dan *sql.Tx yang dipakai query task, sehingga
+// query yang sama bisa dijalankan di dalam maupun di luar transaksi.
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
 }
 
 type taskRepository struct {
 	db          *sql.DB
+	dialect     Dialect
 	redisClient *redis.Client
 	logger      *logrus.Logger
+	cacheTTL    *atomic.Int64
+	// enforceDependencies menyalin tasks.enforce_dependencies; lihat
+	// requireUnblocked.
+	enforceDependencies *atomic.Bool
+
+	// q adalah db, atau transaksi jika repository dibuat oleh UnitOfWork
+	// (scope tidak nil).
+	q     dbtx
+	scope *txScope
+}
+
+// TaskStore adalah TaskRepository buatan NewTaskRepository. UnitOfWork serta
+// repository tag, project, checklist dan dependensi dibangun di atasnya agar
+// memakai koneksi, cache dan transaksi yang sama; method yang tidak diekspor
+// membuat implementasi lain (mock atau wrapper) ditolak saat kompilasi.
+type TaskStore interface {
+	TaskRepository
+	store() *taskRepository
+}
+
+func (r *taskRepository) store() *taskRepository {
+	return r
+}
+
+func NewTaskRepository(db *sql.DB, dialect Dialect, redisClient *redis.Client, watcher *config.Watcher, logger *logrus.Logger) TaskStore {
+	r := &taskRepository{
+		db:                  db,
+		dialect:             dialect,
+		redisClient:         redisClient,
+		logger:              logger,
+		cacheTTL:            new(atomic.Int64),
+		enforceDependencies: new(atomic.Bool),
+		q:                   db,
+	}
+
+	// TTL cache dan pemeriksaan dependensi bisa diubah saat runtime lewat
+	// reload konfigurasi
+	watcher.Subscribe(func(cfg config.Config) {
+		r.cacheTTL.Store(int64(cfg.Cache.TaskTTL))
+		r.enforceDependencies.Store(cfg.Tasks.EnforceDependencies)
+	})
+
+	return r
 }
 
-func NewTaskRepository(db *sql.DB, redisClient *redis.Client, logger *logrus.Logger) TaskRepository {
-	return &taskRepository{
-		db:          db,
-		redisClient: redisClient,
-		logger:      logger,
+// CreateTask menyimpan task lalu mengisi ID, Version, CreatedAt dan
+// UpdatedAt dengan nilai yang dibuat database.
+func (r *taskRepository) CreateTask(ctx context.Context, task *models.Task) (err error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.CreateTask")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	// Transaksi menahan kunci baris project dari requireOpenProject sampai
+	// INSERT selesai, sehingga project tidak bisa diarsipkan di antaranya
+	err = r.transaction(ctx, func(tx *taskRepository) error {
+		return tx.insertTask(ctx, task)
+	})
+	if err != nil {
+		return err
 	}
+	span.SetAttributes(attribute.Int("task.id", int(task.ID)))
+	return nil
 }
 
-func (r *taskRepository) CreateTask(task *models.Task) error {
-	query := "INSERT INTO tasks (title, description, status, created_at, updated_at) VALUES (?, ?, ?, ?, ?)"
-	_, err := r.db.Exec(query, task.Title, task.Description, task.Status, time.Now(), time.Now())
-	return err
+func (r *taskRepository) insertTask(ctx context.Context, task *models.Task) (err error) {
+	task.Archived = false
+	if task.ProjectID != nil {
+		if err := requireOpenProject(ctx, r, *task.ProjectID); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	query := "INSERT INTO tasks (title, description, status, due_date, priority, project_id, parent_id, auto_complete, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	args := []interface{}{task.Title, task.Description, task.Status, task.DueDate, task.Priority, projectArg(task.ProjectID), parentArg(task.ParentID), boolInt(task.AutoComplete), now, now}
+
+	var id, version int64
+	switch r.dialect {
+	case Oracle:
+		// godror tidak mendukung RETURNING sebagai result set; nilainya
+		// dikembalikan lewat bind variable keluaran
+		query += " RETURNING id, version, created_at, updated_at INTO ?, ?, ?, ?"
+		args = append(args,
+			sql.Out{Dest: &id},
+			sql.Out{Dest: &version},
+			sql.Out{Dest: &task.CreatedAt},
+			sql.Out{Dest: &task.UpdatedAt},
+		)
+		_, err = r.q.ExecContext(ctx, r.dialect.Rebind(query), args...)
+	default:
+		query += " RETURNING id, version, created_at, updated_at"
+		err = r.q.QueryRowContext(ctx, r.dialect.Rebind(query), args...).Scan(&id, &version, &task.CreatedAt, &task.UpdatedAt)
+	}
+	if err != nil {
+		return err
+	}
+
+	task.ID = uint(id)
+	task.Version = uint(version)
+	task.Tags = []models.Tag{}
+	task.Checklist = []models.ChecklistItem{}
+	return nil
 }
 
-func (r *taskRepository) GetTaskByID(id uint) (*models.Task, error) {
-	ctx := context.Background()
+func (r *taskRepository) GetTaskByID(ctx context.Context, id uint) (_ *models.Task, err error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.GetTaskByID", trace.WithAttributes(attribute.Int("task.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	// Di dalam unit of work baris dikunci sampai commit dan cache dilewati:
+	// cache bisa lebih lama dari data transaksi, dan data yang belum di-commit
+	// tidak boleh masuk cache
+	if r.scope != nil {
+		return r.selectTask(ctx, id, r.dialect.ForUpdate())
+	}
+
 	cacheKey := fmt.Sprintf("task:%d", id)
 
 	// Cek Cache
@@ -55,94 +223,319 @@ func (r *taskRepository) GetTaskByID(id uint) (*models.Task, error) {
 	if err == nil {
 		var task models.Task
 		if err := json.Unmarshal([]byte(cachedTask), &task); err == nil {
+			span.SetAttributes(attribute.Bool("cache.hit", true))
 			return &task, nil
 		}
 	}
+	span.SetAttributes(attribute.Bool("cache.hit", false))
 
 	// Ambil dari Database
-	query := "SELECT id, title, description, status, created_at, updated_at FROM tasks WHERE id = ?"
-	row := r.db.QueryRow(query, id)
-
-	var task models.Task
-	if err := row.Scan(&task.ID, &task.Title, &task.Description, &task.Status, &task.CreatedAt, &task.UpdatedAt); err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("task not found")
-		}
+	task, err := r.selectTask(ctx, id, "")
+	if err != nil {
 		return nil, err
 	}
 
 	// Simpan ke Cache
 	taskJSON, _ := json.Marshal(task)
-	r.redisClient.Set(ctx, cacheKey, taskJSON, time.Minute*10)
+	r.redisClient.Set(ctx, cacheKey, taskJSON, time.Duration(r.cacheTTL.Load()))
 
-	return &task, nil
+	return task, nil
 }
 
-func (r *taskRepository) GetAllTasks(filter map[string]interface{}, pagination Pagination, search string) ([]models.Task, int64, error) {
-	var tasks []models.Task
-	var total int64
+// selectTask membaca satu task langsung dari database; lock ditambahkan di
+// akhir query (mis. " FOR UPDATE").
+func (r *taskRepository) selectTask(ctx context.Context, id uint, lock string) (*models.Task, error) {
+	query := "SELECT " + taskColumns + " FROM tasks WHERE id = ?" + lock
+	row := r.q.QueryRowContext(ctx, r.dialect.Rebind(query), id)
 
-	// Ambil status dari filter
-	status, _ := filter["status"].(string)
+	tasks := make([]models.Task, 1)
+	if err := row.Scan(taskDest(&tasks[0])...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, taskNotFound(id)
+		}
+		return nil, err
+	}
+	if err := r.loadRelated(ctx, tasks); err != nil {
+		return nil, err
+	}
+	return &tasks[0], nil
+}
 
-	// Base Query untuk mengambil data task
-	query := "SELECT id, title, description, status, created_at, updated_at FROM tasks WHERE 1=1"
-	countQuery := "SELECT COUNT(*) FROM tasks WHERE 1=1"
+func (r *taskRepository) GetAllTasks(ctx context.Context, where filter.Expr, pagination Pagination) (_ *TaskPage, err error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.GetAllTasks", trace.WithAttributes(
+		attribute.Int("pagination.page", pagination.Page),
+		attribute.Int("pagination.limit", pagination.Limit),
+		attribute.Bool("pagination.keyset", pagination.After != nil || pagination.Before != nil),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	// Tanpa pg_trgm, pencarian fuzzy dihitung di proses
+	if fuzzy, rest, ok := splitFuzzy(where); ok && r.dialect != Postgres {
+		return r.fuzzyTasks(ctx, fuzzy, rest, pagination)
+	}
 
-	var args []interface{}
+	page := &TaskPage{Total: -1}
+
+	// Base Query untuk mengambil data task
+	whereSQL, args, err := r.dialect.taskWhere(where)
+	if err != nil {
+		return nil, err
+	}
 
-	// Filter Berdasarkan Status
-	if status != "" {
-		query += " AND status = ?"
-		countQuery += " AND status = ?"
-		args = append(args, status)
+	// Eksekusi Count Query, sebelum argumen cursor dan pagination ditambahkan
+	if !pagination.SkipCount {
+		row := r.q.QueryRowContext(ctx, r.dialect.Rebind("SELECT COUNT(*) FROM tasks"+whereSQL), args...)
+		if err := row.Scan(&page.Total); err != nil {
+			return nil, err
+		}
 	}
 
-	// Search Berdasarkan Title atau Description
-	if search != "" {
-		query += " AND (title LIKE ? OR description LIKE ?)"
-		countQuery += " AND (title LIKE ? OR description LIKE ?)"
-		searchParam := "%" + search + "%"
-		args = append(args, searchParam, searchParam)
+	// Keyset: lanjut dari baris batas, mundur dengan urutan terbalik untuk Before
+	boundary, backward := pagination.After, false
+	if pagination.Before != nil {
+		boundary, backward = pagination.Before, true
+	}
+	offset := 0
+	if boundary != nil {
+		keyset, keysetArgs, err := keysetWhere(pagination.Sort, *boundary, backward)
+		if err != nil {
+			return nil, err
+		}
+		whereSQL += keyset
+		args = append(args, keysetArgs...)
+	} else {
+		offset = (pagination.Page - 1) * pagination.Limit
 	}
 
-	// Pagination - hitung offset dan limit
-	offset := (pagination.Page - 1) * pagination.Limit
-	query += " LIMIT ? OFFSET ?"
-	args = append(args, pagination.Limit, offset)
+	// Pencarian menambah kolom relevansi dan cuplikan; argumennya mendahului
+	// argumen WHERE karena posisinya lebih dulu di query
+	columns := taskColumns
+	ranking := filter.Ranking(where)
+	ranked := ranking != nil
+	if ranked {
+		rankColumns, rankArgs := r.dialect.rankColumns(ranking)
+		columns += rankColumns
+		args = append(rankArgs, args...)
+		page.Hits = make(map[uint]SearchHit)
+	}
 
-	// Eksekusi Count Query
-	row := r.db.QueryRow(countQuery, args...)
-	if err := row.Scan(&total); err != nil {
-		return nil, 0, err
+	orderBy, err := orderBy(pagination.Sort, backward, ranked)
+	if err != nil {
+		return nil, err
 	}
+	query := "SELECT " + columns + " FROM tasks" + whereSQL + orderBy
+
+	// Satu baris tambahan menandakan masih ada halaman berikutnya tanpa COUNT
+	query, args = r.dialect.Paginate(query, args, pagination.Limit+1, offset)
 
 	// Eksekusi Query untuk mengambil Data Task
-	rows, err := r.db.Query(query, args...)
+	rows, err := r.q.QueryContext(ctx, r.dialect.Rebind(query), args...)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
 		var task models.Task
-		if err := rows.Scan(&task.ID, &task.Title, &task.Description, &task.Status, &task.CreatedAt, &task.UpdatedAt); err != nil {
-			return nil, 0, err
+		var rank sql.NullFloat64
+		var snippet sql.NullString
+		dest := taskDest(&task)
+		if ranked {
+			dest = append(dest, &rank, &snippet)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		page.Tasks = append(page.Tasks, task)
+		if ranked {
+			page.Hits[task.ID] = SearchHit{Rank: rank.Float64, Snippet: highlight(snippet.String)}
 		}
-		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
-	return tasks, total, nil
+	more := len(page.Tasks) > pagination.Limit
+	if more {
+		page.Tasks = page.Tasks[:pagination.Limit]
+	}
+	switch {
+	case pagination.Before != nil:
+		slices.Reverse(page.Tasks)
+		page.HasPrev, page.HasNext = more, true
+	case pagination.After != nil:
+		page.HasPrev, page.HasNext = true, more
+	default:
+		page.HasPrev, page.HasNext = offset > 0, more
+	}
+	if err := r.loadRelated(ctx, page.Tasks); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// taskColumns adalah kolom task yang dibaca query SELECT, sesuai urutan
+// taskDest.
+const taskColumns = "id, title, description, status, due_date, priority, version, project_id, archived, parent_id, auto_complete, created_at, updated_at"
+
+func taskDest(task *models.Task) []interface{} {
+	return []interface{}{&task.ID, &task.Title, &task.Description, &task.Status, &task.DueDate, &task.Priority, &task.Version,
+		&task.ProjectID, &task.Archived, &task.ParentID, &task.AutoComplete, &task.CreatedAt, &task.UpdatedAt}
+}
+
+// loadRelated mengisi data task yang disimpan di tabel lain (tag dan
+// checklist).
+func (r *taskRepository) loadRelated(ctx context.Context, tasks []models.Task) error {
+	if err := r.loadTags(ctx, tasks); err != nil {
+		return err
+	}
+	return r.loadChecklists(ctx, tasks)
+}
+
+// taskWhere menyusun klausa WHERE dari filter yang dipakai GetAllTasks dan
+// operasi berdasarkan filter.
+func (d Dialect) taskWhere(where filter.Expr) (string, []interface{}, error) {
+	if where == nil {
+		return " WHERE 1=1", nil, nil
+	}
+	cond, args, err := d.compileFilter(where)
+	if err != nil {
+		return "", nil, err
+	}
+	return " WHERE " + cond, args, nil
+}
+
+// UpdateTask menyimpan task dan menaikkan versinya. Jika task.Version > 0,
+// update hanya terjadi bila versi di database masih sama (optimistic
+// concurrency); task.Version diperbarui ke versi baru setelah berhasil.
+func (r *taskRepository) UpdateTask(ctx context.Context, task *models.Task) (err error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.UpdateTask", trace.WithAttributes(attribute.Int("task.id", int(task.ID))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if err := r.updateTask(ctx, task); err != nil {
+		return err
+	}
+
+	r.invalidateCache(ctx, task.ID)
+	return nil
 }
 
-func (r *taskRepository) UpdateTask(task *models.Task) error {
-	query := "UPDATE tasks SET title = ?, description = ?, status = ?, updated_at = ? WHERE id = ?"
-	_, err := r.db.Exec(query, task.Title, task.Description, task.Status, time.Now(), task.ID)
-	return err
+func (r *taskRepository) updateTask(ctx context.Context, task *models.Task) error {
+	if task.Status == "completed" {
+		if err := requireUnblocked(ctx, r, []uint{task.ID}, nil); err != nil {
+			return err
+		}
+	}
+
+	query := "UPDATE tasks SET title = ?, description = ?, status = ?, due_date = ?, priority = ?, auto_complete = ?, updated_at = ?, version = version + 1 WHERE id = ?"
+	args := []interface{}{task.Title, task.Description, task.Status, task.DueDate, task.Priority, boolInt(task.AutoComplete), time.Now(), task.ID}
+	if task.Version > 0 {
+		query += " AND version = ?"
+		args = append(args, task.Version)
+	}
+
+	result, err := r.q.ExecContext(ctx, r.dialect.Rebind(query), args...)
+	if err != nil {
+		return err
+	}
+	if err := r.requireAffected(ctx, result, task.ID, task.Version); err != nil {
+		return err
+	}
+	if task.Version > 0 {
+		task.Version++
+	}
+	return nil
 }
 
-func (r *taskRepository) DeleteTask(id uint) error {
+func (r *taskRepository) DeleteTask(ctx context.Context, id uint, expectedVersion uint) (err error) {
+	ctx, span := tracer.Start(ctx, "TaskRepository.DeleteTask", trace.WithAttributes(attribute.Int("task.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	// Subtask dilepas dan task dihapus dalam satu transaksi
+	return r.transaction(ctx, func(tx *taskRepository) error {
+		if err := tx.deleteTask(ctx, id, expectedVersion); err != nil {
+			return err
+		}
+		tx.invalidateCache(ctx, id)
+		return nil
+	})
+}
+
+func (r *taskRepository) deleteTask(ctx context.Context, id uint, expectedVersion uint) error {
+	if expectedVersion > 0 {
+		// Subtask hanya dilepas jika versi parent cocok
+		if _, err := lockTask(ctx, r, id, expectedVersion); err != nil {
+			return err
+		}
+	}
+	if err := detachSubtasks(ctx, r, []uint{id}); err != nil {
+		return err
+	}
+
 	query := "DELETE FROM tasks WHERE id = ?"
-	_, err := r.db.Exec(query, id)
-	return err
+	args := []interface{}{id}
+	if expectedVersion > 0 {
+		query += " AND version = ?"
+		args = append(args, expectedVersion)
+	}
+
+	result, err := r.q.ExecContext(ctx, r.dialect.Rebind(query), args...)
+	if err != nil {
+		return err
+	}
+	return r.requireAffected(ctx, result, id, expectedVersion)
+}
+
+// invalidateCache menghapus cache task agar GetTaskByID tidak mengembalikan
+// data lama. Di dalam unit of work penghapusan ditunda sampai commit supaya
+// pembaca lain tidak mengisi ulang cache dengan data sebelum commit, dan
+// dibatalkan jika transaksi di-rollback.
+func (r *taskRepository) invalidateCache(ctx context.Context, id uint) {
+	if r.scope != nil {
+		r.scope.afterCommit(func() { r.deleteCache(ctx, id) })
+		return
+	}
+	r.deleteCache(ctx, id)
+}
+
+func (r *taskRepository) deleteCache(ctx context.Context, id uint) {
+	if err := r.redisClient.Del(ctx, fmt.Sprintf("task:%d", id)).Err(); err != nil {
+		r.logger.WithError(err).WithField("task_id", id).Warn("Failed to invalidate task cache")
+	}
+}
+
+func taskNotFound(id uint) error {
+	return apperrors.NotFound("task_not_found", fmt.Sprintf("Task %d not found", id)).WithParams(strconv.FormatUint(uint64(id), 10))
+}
+
+// VersionMismatch adalah error untuk task id yang versinya tidak sama dengan
+// versi yang diharapkan (If-Match).
+func VersionMismatch(id uint) error {
+	return apperrors.PreconditionFailed("task_version_mismatch", fmt.Sprintf("Task %d was modified by someone else", id)).
+		WithParams(strconv.FormatUint(uint64(id), 10))
+}
+
+// requireAffected memastikan statement mengubah satu baris. Jika tidak ada
+// baris yang berubah padahal versi diperiksa, task masih ada tetapi versinya
+// sudah berbeda (ErrPreconditionFailed); selain itu task tidak ada.
+func (r *taskRepository) requireAffected(ctx context.Context, result sql.Result, id uint, expectedVersion uint) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+	if expectedVersion == 0 {
+		return taskNotFound(id)
+	}
+
+	var count int
+	if err := r.q.QueryRowContext(ctx, r.dialect.Rebind("SELECT COUNT(*) FROM tasks WHERE id = ?"), id).Scan(&count); err != nil {
+		return err
+	}
+	if count == 0 {
+		return taskNotFound(id)
+	}
+	return VersionMismatch(id)
 }
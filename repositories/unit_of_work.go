@@ -0,0 +1,107 @@
+// repositories/unit_of_work.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/tracing"
+)
+
+// Repositories adalah kumpulan repository. Di dalam UnitOfWork.Do semuanya
+// memakai transaksi yang sama.
+type Repositories struct {
+	Tasks TaskStore
+}
+
+// UnitOfWork menjalankan beberapa panggilan repository secara atomik.
+type UnitOfWork interface {
+	// Do menjalankan fn di dalam satu transaksi. Jika fn mengembalikan error
+	// (atau panic) transaksi di-rollback dan tidak ada cache yang dibuang;
+	// jika tidak, transaksi di-commit lalu cache yang terdampak dibuang.
+	// Task yang dibaca lewat repos dikunci sampai transaksi selesai.
+	// Do tidak boleh dipanggil bertingkat.
+	Do(ctx context.Context, fn func(repos Repositories) error) error
+}
+
+type unitOfWork struct {
+	db    *sql.DB
+	repos Repositories
+}
+
+// NewUnitOfWork membuat UnitOfWork untuk repos yang dibuat oleh package ini
+// (mis. NewTaskRepository) di atas db yang sama.
+func NewUnitOfWork(db *sql.DB, repos Repositories) UnitOfWork {
+	return &unitOfWork{db: db, repos: repos}
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn func(repos Repositories) error) (err error) {
+	ctx, span := tracer.Start(ctx, "UnitOfWork.Do")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return runInTx(ctx, u.db, func(scope *txScope) error {
+		return fn(Repositories{
+			Tasks: u.repos.Tasks.store().withTx(scope),
+		})
+	})
+}
+
+// txScope adalah transaksi yang sedang berjalan beserta pekerjaan yang
+// menunggu commit.
+type txScope struct {
+	tx      *sql.Tx
+	pending []func()
+}
+
+func (s *txScope) afterCommit(fn func()) {
+	s.pending = append(s.pending, fn)
+}
+
+// runInTx menjalankan fn dalam transaksi baru dan menjalankan afterCommit
+// hanya setelah commit berhasil.
+func runInTx(ctx context.Context, db *sql.DB, fn func(scope *txScope) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	// Rollback setelah Commit tidak berpengaruh; juga menangani panic di fn
+	defer tx.Rollback()
+
+	scope := &txScope{tx: tx}
+	if err := fn(scope); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	for _, pending := range scope.pending {
+		pending()
+	}
+	return nil
+}
+
+// withTx mengembalikan salinan repository yang memakai transaksi scope.
+func (r *taskRepository) withTx(scope *txScope) *taskRepository {
+	return &taskRepository{
+		db:                  r.db,
+		dialect:             r.dialect,
+		redisClient:         r.redisClient,
+		logger:              r.logger,
+		cacheTTL:            r.cacheTTL,
+		enforceDependencies: r.enforceDependencies,
+		q:                   scope.tx,
+		scope:               scope,
+	}
+}
+
+// transaction menjalankan fn di transaksi unit of work yang sedang berjalan,
+// atau di transaksi baru jika repository tidak berada di dalam unit of work.
+func (r *taskRepository) transaction(ctx context.Context, fn func(tx *taskRepository) error) error {
+	if r.scope != nil {
+		return fn(r)
+	}
+	return runInTx(ctx, r.db, func(scope *txScope) error {
+		return fn(r.withTx(scope))
+	})
+}
@@ -0,0 +1,301 @@
+// repositories/view_repository.go
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ViewRepository menyimpan saved view beserta daftar user yang menerima
+// bagiannya dan view bawaan setiap user. Pemeriksaan hak akses ada di
+// service.
+type ViewRepository interface {
+	// CreateView menyimpan view lalu mengisi ID, CreatedAt dan UpdatedAt.
+	// Nama view harus unik per owner.
+	CreateView(ctx context.Context, view *models.View) error
+	GetView(ctx context.Context, id uint) (*models.View, error)
+	// ListViews mengembalikan view milik username dan view yang dibagikan
+	// kepadanya, urut nama.
+	ListViews(ctx context.Context, username string) ([]models.View, error)
+	// UpdateView mengganti semua field view yang bisa diubah, termasuk
+	// SharedWith.
+	UpdateView(ctx context.Context, view *models.View) error
+	DeleteView(ctx context.Context, id uint) error
+	// DefaultViewID mengembalikan ID view bawaan username, 0 jika tidak ada.
+	DefaultViewID(ctx context.Context, username string) (uint, error)
+	// SetDefaultView menjadikan view id sebagai view bawaan username; id 0
+	// menghapusnya.
+	SetDefaultView(ctx context.Context, username string, id uint) error
+}
+
+type viewRepository struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+func NewViewRepository(db *sql.DB, dialect Dialect) ViewRepository {
+	return &viewRepository{db: db, dialect: dialect}
+}
+
+// viewColumns dibaca oleh scanView. Kolom teks bisa NULL (string kosong di
+// Oracle) sehingga di-COALESCE.
+const viewColumns = "id, owner, name, COALESCE(filter_query, ''), COALESCE(search_query, ''), COALESCE(sort_order, ''), page_size, created_at, updated_at"
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanView(row rowScanner) (models.View, error) {
+	var v models.View
+	err := row.Scan(&v.ID, &v.Owner, &v.Name, &v.Filter, &v.Search, &v.Sort, &v.PageSize, &v.CreatedAt, &v.UpdatedAt)
+	return v, err
+}
+
+func (r *viewRepository) CreateView(ctx context.Context, view *models.View) (err error) {
+	ctx, span := tracer.Start(ctx, "ViewRepository.CreateView")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return runInTx(ctx, r.db, func(scope *txScope) error {
+		if err := r.requireUniqueName(ctx, scope.tx, view); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		query := "INSERT INTO views (owner, name, filter_query, search_query, sort_order, page_size, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+		args := []interface{}{view.Owner, view.Name, view.Filter, view.Search, view.Sort, view.PageSize, now, now}
+
+		var id int64
+		var err error
+		switch r.dialect {
+		case Oracle:
+			query += " RETURNING id INTO ?"
+			_, err = scope.tx.ExecContext(ctx, r.dialect.Rebind(query), append(args, sql.Out{Dest: &id})...)
+		default:
+			query += " RETURNING id"
+			err = scope.tx.QueryRowContext(ctx, r.dialect.Rebind(query), args...).Scan(&id)
+		}
+		if err != nil {
+			return err
+		}
+
+		view.ID = uint(id)
+		view.CreatedAt, view.UpdatedAt = now, now
+		span.SetAttributes(attribute.Int("view.id", int(view.ID)))
+		return r.insertShares(ctx, scope.tx, view)
+	})
+}
+
+func (r *viewRepository) GetView(ctx context.Context, id uint) (_ *models.View, err error) {
+	ctx, span := tracer.Start(ctx, "ViewRepository.GetView", trace.WithAttributes(attribute.Int("view.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	row := r.db.QueryRowContext(ctx, r.dialect.Rebind("SELECT "+viewColumns+" FROM views WHERE id = ?"), id)
+	view, err := scanView(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ViewNotFound(id)
+		}
+		return nil, err
+	}
+
+	shares, err := r.shares(ctx, "view_id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+	view.SharedWith = shares[view.ID]
+	return &view, nil
+}
+
+func (r *viewRepository) ListViews(ctx context.Context, username string) (_ []models.View, err error) {
+	ctx, span := tracer.Start(ctx, "ViewRepository.ListViews")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	visible := "owner = ? OR id IN (SELECT view_id FROM view_shares WHERE username = ?)"
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind("SELECT "+viewColumns+" FROM views WHERE "+visible+" ORDER BY name, id"), username, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	views := []models.View{}
+	for rows.Next() {
+		view, err := scanView(rows)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	shares, err := r.shares(ctx, "view_id IN (SELECT id FROM views WHERE "+visible+")", username, username)
+	if err != nil {
+		return nil, err
+	}
+	for i := range views {
+		views[i].SharedWith = shares[views[i].ID]
+	}
+	return views, nil
+}
+
+// shares membaca penerima bagian view yang cocok dengan kondisi where,
+// dikelompokkan per ID view.
+func (r *viewRepository) shares(ctx context.Context, where string, args ...interface{}) (map[uint][]string, error) {
+	rows, err := r.db.QueryContext(ctx, r.dialect.Rebind("SELECT view_id, username FROM view_shares WHERE "+where+" ORDER BY view_id, username"), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shares := make(map[uint][]string)
+	for rows.Next() {
+		var id uint
+		var username string
+		if err := rows.Scan(&id, &username); err != nil {
+			return nil, err
+		}
+		shares[id] = append(shares[id], username)
+	}
+	return shares, rows.Err()
+}
+
+func (r *viewRepository) UpdateView(ctx context.Context, view *models.View) (err error) {
+	ctx, span := tracer.Start(ctx, "ViewRepository.UpdateView", trace.WithAttributes(attribute.Int("view.id", int(view.ID))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return runInTx(ctx, r.db, func(scope *txScope) error {
+		if err := r.requireUniqueName(ctx, scope.tx, view); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		result, err := scope.tx.ExecContext(ctx, r.dialect.Rebind("UPDATE views SET name = ?, filter_query = ?, search_query = ?, sort_order = ?, page_size = ?, updated_at = ? WHERE id = ?"),
+			view.Name, view.Filter, view.Search, view.Sort, view.PageSize, now, view.ID)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return ViewNotFound(view.ID)
+		}
+		view.UpdatedAt = now
+
+		if _, err := scope.tx.ExecContext(ctx, r.dialect.Rebind("DELETE FROM view_shares WHERE view_id = ?"), view.ID); err != nil {
+			return err
+		}
+		// Penerima yang dicabut kehilangan view ini sebagai view bawaannya
+		if err := r.dropRevokedDefaults(ctx, scope.tx, view); err != nil {
+			return err
+		}
+		return r.insertShares(ctx, scope.tx, view)
+	})
+}
+
+func (r *viewRepository) DeleteView(ctx context.Context, id uint) (err error) {
+	ctx, span := tracer.Start(ctx, "ViewRepository.DeleteView", trace.WithAttributes(attribute.Int("view.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	// Baris anak dihapus eksplisit karena SQLite hanya menjalankan ON DELETE
+	// CASCADE jika PRAGMA foreign_keys aktif
+	return runInTx(ctx, r.db, func(scope *txScope) error {
+		for _, query := range []string{
+			"DELETE FROM view_defaults WHERE view_id = ?",
+			"DELETE FROM view_shares WHERE view_id = ?",
+		} {
+			if _, err := scope.tx.ExecContext(ctx, r.dialect.Rebind(query), id); err != nil {
+				return err
+			}
+		}
+		result, err := scope.tx.ExecContext(ctx, r.dialect.Rebind("DELETE FROM views WHERE id = ?"), id)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return ViewNotFound(id)
+		}
+		return nil
+	})
+}
+
+func (r *viewRepository) DefaultViewID(ctx context.Context, username string) (_ uint, err error) {
+	ctx, span := tracer.Start(ctx, "ViewRepository.DefaultViewID")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	var id uint
+	err = r.db.QueryRowContext(ctx, r.dialect.Rebind("SELECT view_id FROM view_defaults WHERE username = ?"), username).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return id, err
+}
+
+func (r *viewRepository) SetDefaultView(ctx context.Context, username string, id uint) (err error) {
+	ctx, span := tracer.Start(ctx, "ViewRepository.SetDefaultView", trace.WithAttributes(attribute.Int("view.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	// DELETE lalu INSERT karena sintaks upsert berbeda di setiap dialek
+	return runInTx(ctx, r.db, func(scope *txScope) error {
+		if _, err := scope.tx.ExecContext(ctx, r.dialect.Rebind("DELETE FROM view_defaults WHERE username = ?"), username); err != nil {
+			return err
+		}
+		if id == 0 {
+			return nil
+		}
+		_, err := scope.tx.ExecContext(ctx, r.dialect.Rebind("INSERT INTO view_defaults (username, view_id) VALUES (?, ?)"), username, id)
+		return err
+	})
+}
+
+// requireUniqueName menolak nama yang sudah dipakai view lain milik owner
+// yang sama, sebelum constraint UNIQUE gagal dengan error khas driver.
+func (r *viewRepository) requireUniqueName(ctx context.Context, tx *sql.Tx, view *models.View) error {
+	var count int
+	err := tx.QueryRowContext(ctx, r.dialect.Rebind("SELECT COUNT(*) FROM views WHERE owner = ? AND name = ? AND id <> ?"), view.Owner, view.Name, view.ID).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return apperrors.Conflict("view_name_taken", fmt.Sprintf("A view named %q already exists", view.Name)).WithParams(view.Name)
+	}
+	return nil
+}
+
+func (r *viewRepository) dropRevokedDefaults(ctx context.Context, tx *sql.Tx, view *models.View) error {
+	query := "DELETE FROM view_defaults WHERE view_id = ? AND username <> ?"
+	args := []interface{}{view.ID, view.Owner}
+	for _, username := range view.SharedWith {
+		query += " AND username <> ?"
+		args = append(args, username)
+	}
+	_, err := tx.ExecContext(ctx, r.dialect.Rebind(query), args...)
+	return err
+}
+
+func (r *viewRepository) insertShares(ctx context.Context, tx *sql.Tx, view *models.View) error {
+	for _, username := range view.SharedWith {
+		if _, err := tx.ExecContext(ctx, r.dialect.Rebind("INSERT INTO view_shares (view_id, username) VALUES (?, ?)"), view.ID, username); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ViewNotFound adalah error untuk view id yang tidak ada atau tidak boleh
+// dilihat user.
+func ViewNotFound(id uint) error {
+	return apperrors.NotFound("view_not_found", fmt.Sprintf("View %d not found", id)).WithParams(strconv.FormatUint(uint64(id), 10))
+}
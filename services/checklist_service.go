@@ -0,0 +1,73 @@
+// services/checklist_service.go
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/programmercintasunnah/go-todolist-ilcs/tracing"
+	"github.com/programmercintasunnah/go-todolist-ilcs/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ChecklistService mengelola item checklist di dalam task. Semua method
+// menolak perubahan dengan ErrPreconditionFailed jika expectedVersion > 0
+// tidak lagi sama dengan versi task.
+type ChecklistService interface {
+	AddItem(ctx context.Context, taskID uint, expectedVersion uint, item *models.ChecklistItem) (*models.Task, error)
+	UpdateItem(ctx context.Context, taskID uint, expectedVersion uint, item models.ChecklistItem) (*models.Task, error)
+	DeleteItem(ctx context.Context, taskID uint, expectedVersion uint, itemID uint) (*models.Task, error)
+}
+
+type checklistService struct {
+	repo repositories.ChecklistRepository
+}
+
+func NewChecklistService(repo repositories.ChecklistRepository) ChecklistService {
+	return &checklistService{repo: repo}
+}
+
+func (s *checklistService) AddItem(ctx context.Context, taskID uint, expectedVersion uint, item *models.ChecklistItem) (_ *models.Task, err error) {
+	ctx, span := tracer.Start(ctx, "ChecklistService.AddItem", trace.WithAttributes(attribute.Int("task.id", int(taskID))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if err := prepareChecklistItem(item); err != nil {
+		return nil, err
+	}
+	return s.repo.AddItem(ctx, taskID, expectedVersion, item)
+}
+
+func (s *checklistService) UpdateItem(ctx context.Context, taskID uint, expectedVersion uint, item models.ChecklistItem) (_ *models.Task, err error) {
+	ctx, span := tracer.Start(ctx, "ChecklistService.UpdateItem", trace.WithAttributes(
+		attribute.Int("task.id", int(taskID)),
+		attribute.Int("checklist_item.id", int(item.ID)),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if err := prepareChecklistItem(&item); err != nil {
+		return nil, err
+	}
+	return s.repo.UpdateItem(ctx, taskID, expectedVersion, item)
+}
+
+func (s *checklistService) DeleteItem(ctx context.Context, taskID uint, expectedVersion uint, itemID uint) (_ *models.Task, err error) {
+	ctx, span := tracer.Start(ctx, "ChecklistService.DeleteItem", trace.WithAttributes(
+		attribute.Int("task.id", int(taskID)),
+		attribute.Int("checklist_item.id", int(itemID)),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return s.repo.DeleteItem(ctx, taskID, expectedVersion, itemID)
+}
+
+// prepareChecklistItem merapikan teks lalu memvalidasi item.
+func prepareChecklistItem(item *models.ChecklistItem) error {
+	item.Text = strings.TrimSpace(item.Text)
+	if err := utils.Validate.Struct(item); err != nil {
+		return utils.BindingError(err)
+	}
+	return nil
+}
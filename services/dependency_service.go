@@ -0,0 +1,58 @@
+// services/dependency_service.go
+package services
+
+import (
+	"context"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/programmercintasunnah/go-todolist-ilcs/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DependencyService mengelola dependensi "task diblokir oleh task lain".
+// Selama tasks.enforce_dependencies aktif, task tidak bisa diselesaikan
+// sebelum semua pemblokirnya completed; pemeriksaan itu dilakukan oleh
+// TaskRepository agar berlaku di semua jalur update.
+type DependencyService interface {
+	// LinkTasks mencatat taskID diblokir oleh blockerID; ditolak dengan
+	// ErrConflict jika sudah ada atau akan membentuk siklus.
+	LinkTasks(ctx context.Context, taskID, blockerID uint) error
+	UnlinkTasks(ctx context.Context, taskID, blockerID uint) error
+	GetGraph(ctx context.Context, taskID uint) (*repositories.DependencyGraph, error)
+}
+
+type dependencyService struct {
+	repo repositories.DependencyRepository
+}
+
+func NewDependencyService(repo repositories.DependencyRepository) DependencyService {
+	return &dependencyService{repo: repo}
+}
+
+func (s *dependencyService) LinkTasks(ctx context.Context, taskID, blockerID uint) (err error) {
+	ctx, span := tracer.Start(ctx, "DependencyService.LinkTasks", trace.WithAttributes(
+		attribute.Int("task.id", int(taskID)),
+		attribute.Int("task.blocker_id", int(blockerID)),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return s.repo.AddDependency(ctx, taskID, blockerID)
+}
+
+func (s *dependencyService) UnlinkTasks(ctx context.Context, taskID, blockerID uint) (err error) {
+	ctx, span := tracer.Start(ctx, "DependencyService.UnlinkTasks", trace.WithAttributes(
+		attribute.Int("task.id", int(taskID)),
+		attribute.Int("task.blocker_id", int(blockerID)),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return s.repo.RemoveDependency(ctx, taskID, blockerID)
+}
+
+func (s *dependencyService) GetGraph(ctx context.Context, taskID uint) (_ *repositories.DependencyGraph, err error) {
+	ctx, span := tracer.Start(ctx, "DependencyService.GetGraph", trace.WithAttributes(attribute.Int("task.id", int(taskID))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return s.repo.Graph(ctx, taskID)
+}
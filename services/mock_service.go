@@ -2,8 +2,16 @@
 package services
 
 import (
-	"errors"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/filter"
 	"github.com/programmercintasunnah/go-todolist-ilcs/models"
 	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
 )
@@ -12,40 +20,255 @@ type MockTaskService struct {
 	Tasks []models.Task
 }
 
-func (m *MockTaskService) CreateTask(task *models.Task) error {
+func (m *MockTaskService) CreateTask(_ context.Context, task *models.Task) error {
+	for _, existing := range m.Tasks {
+		if existing.ID >= task.ID {
+			task.ID = existing.ID + 1
+		}
+	}
+	if task.ID == 0 {
+		task.ID = 1
+	}
+	task.Version = 1
+	task.CreatedAt = time.Now()
+	task.UpdatedAt = task.CreatedAt
 	m.Tasks = append(m.Tasks, *task)
 	return nil
 }
 
-func (m *MockTaskService) GetTaskByID(id uint) (*models.Task, error) {
+func (m *MockTaskService) GetTaskByID(_ context.Context, id uint) (*models.Task, error) {
 	for _, task := range m.Tasks {
 		if task.ID == id {
 			return &task, nil
 		}
 	}
-	return nil, errors.New("task not found")
+	return nil, mockNotFound(id)
 }
 
-func (m *MockTaskService) GetAllTasks(filter map[string]interface{}, pagination repositories.Pagination, search string) ([]models.Task, int64, error) {
-	return m.Tasks, int64(len(m.Tasks)), nil
+// GetAllTasks menerapkan filter, sort dan pagination (halaman maupun
+// keyset) seperti repository. Relevansi memakai filter.Relevance.
+func (m *MockTaskService) GetAllTasks(_ context.Context, where filter.Expr, pagination repositories.Pagination) (*repositories.TaskPage, error) {
+	var rank func(models.Task) float64
+	ranking := filter.Ranking(where)
+	if ranking != nil {
+		rank = func(task models.Task) float64 { return filter.Relevance(ranking, task) }
+	}
+	compare := func(a, b models.Task) int {
+		return repositories.CompareTasks(a, b, pagination.Sort, rank)
+	}
+
+	var tasks []models.Task
+	for _, task := range m.Tasks {
+		if filter.Match(where, task) {
+			tasks = append(tasks, task)
+		}
+	}
+	slices.SortStableFunc(tasks, compare)
+
+	page := repositories.PageTasks(tasks, pagination, compare)
+	if rank != nil {
+		page.Hits = make(map[uint]repositories.SearchHit, len(page.Tasks))
+		for _, task := range page.Tasks {
+			page.Hits[task.ID] = repositories.SearchHit{Rank: rank(task)}
+		}
+	}
+	return page, nil
 }
 
-func (m *MockTaskService) UpdateTask(id uint, updatedTask *models.Task) error {
+func (m *MockTaskService) UpdateTask(_ context.Context, id uint, updatedTask *models.Task) error {
 	for i, task := range m.Tasks {
 		if task.ID == id {
+			if updatedTask.Version > 0 && updatedTask.Version != task.Version {
+				return repositories.VersionMismatch(id)
+			}
+			updatedTask.ID = id
+			updatedTask.Version = task.Version + 1
 			m.Tasks[i] = *updatedTask
 			return nil
 		}
 	}
-	return errors.New("task not found")
+	return mockNotFound(id)
+}
+
+func (m *MockTaskService) PatchTask(_ context.Context, id uint, expectedVersion uint, apply func(task *models.Task) error) (*models.Task, error) {
+	for i := range m.Tasks {
+		if m.Tasks[i].ID == id {
+			task := m.Tasks[i]
+			if expectedVersion > 0 && expectedVersion != task.Version {
+				return nil, repositories.VersionMismatch(id)
+			}
+			if err := apply(&task); err != nil {
+				return nil, err
+			}
+			task.Version = m.Tasks[i].Version + 1
+			m.Tasks[i] = task
+			return &task, nil
+		}
+	}
+	return nil, mockNotFound(id)
 }
 
-func (m *MockTaskService) DeleteTask(id uint) error {
+func (m *MockTaskService) DeleteTask(_ context.Context, id uint, expectedVersion uint) error {
 	for i, task := range m.Tasks {
 		if task.ID == id {
+			if expectedVersion > 0 && expectedVersion != task.Version {
+				return repositories.VersionMismatch(id)
+			}
 			m.Tasks = append(m.Tasks[:i], m.Tasks[i+1:]...)
 			return nil
 		}
 	}
-	return errors.New("task not found")
+	return mockNotFound(id)
+}
+
+func (m *MockTaskService) BulkTasks(ctx context.Context, ops []repositories.BulkOperation, atomic bool) ([]repositories.BulkResult, error) {
+	snapshot := append([]models.Task(nil), m.Tasks...)
+	results := make([]repositories.BulkResult, len(ops))
+	for i, op := range ops {
+		task := op.Task
+		var err error
+		switch op.Op {
+		case repositories.BulkCreate:
+			err = m.CreateTask(ctx, &task)
+		case repositories.BulkUpdate:
+			err = m.UpdateTask(ctx, task.ID, &task)
+		case repositories.BulkDelete:
+			err = m.DeleteTask(ctx, task.ID, task.Version)
+		}
+		if err != nil {
+			if atomic {
+				m.Tasks = snapshot
+				return nil, &repositories.BulkError{Index: i, Err: err}
+			}
+			results[i].Err = err
+			continue
+		}
+		if op.Op != repositories.BulkDelete {
+			results[i].Task = &task
+		}
+	}
+	return results, nil
+}
+
+func (m *MockTaskService) UpdateTasksByFilter(_ context.Context, where filter.Expr, changes repositories.TaskChanges, dryRun bool) ([]uint, error) {
+	ids := []uint{}
+	for i, task := range m.Tasks {
+		if !filter.Match(where, task) {
+			continue
+		}
+		ids = append(ids, task.ID)
+		if dryRun {
+			continue
+		}
+		if changes.Status != nil {
+			m.Tasks[i].Status = *changes.Status
+		}
+		if changes.DueDate != nil {
+			m.Tasks[i].DueDate = *changes.DueDate
+		}
+		m.Tasks[i].Version++
+	}
+	return ids, nil
+}
+
+func (m *MockTaskService) DeleteTasksByFilter(_ context.Context, where filter.Expr, dryRun bool) ([]uint, error) {
+	ids := []uint{}
+	kept := m.Tasks[:0:0]
+	for _, task := range m.Tasks {
+		if filter.Match(where, task) {
+			ids = append(ids, task.ID)
+			if !dryRun {
+				continue
+			}
+		}
+		kept = append(kept, task)
+	}
+	m.Tasks = kept
+	return ids, nil
+}
+
+// SuggestTitles mengikuti urutan repository: title yang diawali prefix,
+// title dengan kata yang diawali prefix, lalu kecocokan fuzzy.
+func (m *MockTaskService) SuggestTitles(ctx context.Context, prefix string, limit int) ([]repositories.TitleSuggestion, error) {
+	prefix = strings.ToLower(prefix)
+	startsWith := func(task models.Task) bool { return strings.HasPrefix(strings.ToLower(task.Title), prefix) }
+
+	var matches []models.Task
+	for _, task := range m.Tasks {
+		if startsWith(task) || strings.Contains(strings.ToLower(task.Title), " "+prefix) {
+			matches = append(matches, task)
+		}
+	}
+	slices.SortFunc(matches, func(a, b models.Task) int {
+		if startsWith(a) != startsWith(b) {
+			if startsWith(a) {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Or(cmp.Compare(len(a.Title), len(b.Title)), strings.Compare(a.Title, b.Title), cmp.Compare(a.ID, b.ID))
+	})
+
+	var suggestions []repositories.TitleSuggestion
+	seen := make(map[uint]bool)
+	for _, task := range matches[:min(limit, len(matches))] {
+		suggestions = append(suggestions, repositories.TitleSuggestion{ID: task.ID, Title: task.Title, Score: 1})
+		seen[task.ID] = true
+	}
+
+	page, err := m.GetAllTasks(ctx, filter.Fuzzy{Value: prefix}, repositories.Pagination{
+		Page:  1,
+		Limit: limit,
+		Sort:  []repositories.SortField{{Field: repositories.RelevanceSort, Desc: true}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range page.Tasks {
+		if len(suggestions) < limit && !seen[task.ID] {
+			suggestions = append(suggestions, repositories.TitleSuggestion{ID: task.ID, Title: task.Title, Score: page.Hits[task.ID].Rank})
+		}
+	}
+	return suggestions, nil
+}
+
+// GetSubtasks mengembalikan task dengan ParentID id, urut ID.
+func (m *MockTaskService) GetSubtasks(ctx context.Context, id uint) ([]models.Task, error) {
+	if _, err := m.GetTaskByID(ctx, id); err != nil {
+		return nil, err
+	}
+	subtasks := []models.Task{}
+	for _, task := range m.Tasks {
+		if task.ParentID != nil && *task.ParentID == id {
+			subtasks = append(subtasks, task)
+		}
+	}
+	slices.SortFunc(subtasks, func(a, b models.Task) int { return cmp.Compare(a.ID, b.ID) })
+	return subtasks, nil
+}
+
+// SetParent hanya memeriksa versi dan keberadaan parent; batas kedalaman
+// dan auto-complete tidak ditiru.
+func (m *MockTaskService) SetParent(ctx context.Context, id uint, expectedVersion uint, parentID *uint) (*models.Task, error) {
+	if parentID != nil {
+		if _, err := m.GetTaskByID(ctx, *parentID); err != nil {
+			return nil, err
+		}
+	}
+	for i := range m.Tasks {
+		if m.Tasks[i].ID == id {
+			if expectedVersion > 0 && expectedVersion != m.Tasks[i].Version {
+				return nil, repositories.VersionMismatch(id)
+			}
+			m.Tasks[i].ParentID = parentID
+			m.Tasks[i].Version++
+			task := m.Tasks[i]
+			return &task, nil
+		}
+	}
+	return nil, mockNotFound(id)
+}
+
+func mockNotFound(id uint) error {
+	return apperrors.NotFound("task_not_found", fmt.Sprintf("Task %d not found", id)).WithParams(strconv.FormatUint(uint64(id), 10))
 }
@@ -0,0 +1,141 @@
+// services/mock_view_service.go
+package services
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+)
+
+// MockViewService menyimpan view di memori dengan aturan akses yang sama
+// seperti viewService.
+type MockViewService struct {
+	Views    []models.View
+	Defaults map[string]uint
+}
+
+func (m *MockViewService) CreateView(_ context.Context, username string, view *models.View) error {
+	view.Owner = username
+	if err := prepareView(view); err != nil {
+		return err
+	}
+	for _, existing := range m.Views {
+		if existing.Owner == username && existing.Name == view.Name {
+			return apperrors.Conflict("view_name_taken", fmt.Sprintf("A view named %q already exists", view.Name)).WithParams(view.Name)
+		}
+		view.ID = max(view.ID, existing.ID)
+	}
+	view.ID++
+	view.CreatedAt = time.Now()
+	view.UpdatedAt = view.CreatedAt
+	m.Views = append(m.Views, *view)
+	return nil
+}
+
+func (m *MockViewService) GetView(_ context.Context, username string, id uint) (*models.View, error) {
+	i, err := m.visible(username, id)
+	if err != nil {
+		return nil, err
+	}
+	view := m.Views[i]
+	view.IsDefault = m.Defaults[username] == id
+	return &view, nil
+}
+
+func (m *MockViewService) ListViews(_ context.Context, username string) ([]models.View, error) {
+	views := []models.View{}
+	for _, view := range m.Views {
+		if view.VisibleTo(username) {
+			view.IsDefault = m.Defaults[username] == view.ID
+			views = append(views, view)
+		}
+	}
+	slices.SortFunc(views, func(a, b models.View) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+	})
+	return views, nil
+}
+
+func (m *MockViewService) UpdateView(_ context.Context, username string, view *models.View) error {
+	i, err := m.owned(username, view.ID)
+	if err != nil {
+		return err
+	}
+	view.Owner = username
+	view.CreatedAt = m.Views[i].CreatedAt
+	view.UpdatedAt = time.Now()
+	if err := prepareView(view); err != nil {
+		return err
+	}
+	m.Views[i] = *view
+	return nil
+}
+
+func (m *MockViewService) DeleteView(_ context.Context, username string, id uint) error {
+	i, err := m.owned(username, id)
+	if err != nil {
+		return err
+	}
+	m.Views = slices.Delete(m.Views, i, i+1)
+	for user, defaultID := range m.Defaults {
+		if defaultID == id {
+			delete(m.Defaults, user)
+		}
+	}
+	return nil
+}
+
+func (m *MockViewService) SetDefaultView(_ context.Context, username string, id uint) error {
+	if _, err := m.visible(username, id); err != nil {
+		return err
+	}
+	if m.Defaults == nil {
+		m.Defaults = make(map[string]uint)
+	}
+	m.Defaults[username] = id
+	return nil
+}
+
+func (m *MockViewService) ClearDefaultView(_ context.Context, username string, id uint) error {
+	if _, err := m.visible(username, id); err != nil {
+		return err
+	}
+	if m.Defaults[username] == id {
+		delete(m.Defaults, username)
+	}
+	return nil
+}
+
+func (m *MockViewService) DefaultView(ctx context.Context, username string) (*models.View, error) {
+	id, ok := m.Defaults[username]
+	if !ok {
+		return nil, nil
+	}
+	return m.GetView(ctx, username, id)
+}
+
+func (m *MockViewService) visible(username string, id uint) (int, error) {
+	for i, view := range m.Views {
+		if view.ID == id && view.VisibleTo(username) {
+			return i, nil
+		}
+	}
+	return 0, repositories.ViewNotFound(id)
+}
+
+func (m *MockViewService) owned(username string, id uint) (int, error) {
+	i, err := m.visible(username, id)
+	if err != nil {
+		return 0, err
+	}
+	if m.Views[i].Owner != username {
+		return 0, viewNotOwner(id)
+	}
+	return i, nil
+}
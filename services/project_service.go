@@ -0,0 +1,143 @@
+// services/project_service.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/programmercintasunnah/go-todolist-ilcs/tracing"
+	"github.com/programmercintasunnah/go-todolist-ilcs/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ProjectService mengelola project. Semua user bisa melihat project dan
+// memindahkan task ke dalamnya; hanya owner yang boleh mengubah,
+// mengarsipkan atau menghapus project.
+type ProjectService interface {
+	// ListProjects mengembalikan project urut nama; archived nil berarti
+	// semua project.
+	ListProjects(ctx context.Context, archived *bool) ([]repositories.ProjectSummary, error)
+	GetProject(ctx context.Context, id uint) (*repositories.ProjectSummary, error)
+	CreateProject(ctx context.Context, username string, project *models.Project) error
+	UpdateProject(ctx context.Context, username string, project *models.Project) (*repositories.ProjectSummary, error)
+	// ArchiveProject mengarsipkan project (archived true) atau
+	// mengembalikannya; task di dalamnya ikut tersembunyi atau tampil.
+	ArchiveProject(ctx context.Context, username string, id uint, archived bool) error
+	DeleteProject(ctx context.Context, username string, id uint) error
+	// MoveTask memindahkan task ke project projectID, atau keluar dari
+	// project jika nil. Project tujuan tidak boleh diarsipkan.
+	MoveTask(ctx context.Context, taskID uint, expectedVersion uint, projectID *uint) (*models.Task, error)
+}
+
+type projectService struct {
+	repo repositories.ProjectRepository
+}
+
+func NewProjectService(repo repositories.ProjectRepository) ProjectService {
+	return &projectService{repo: repo}
+}
+
+func (s *projectService) ListProjects(ctx context.Context, archived *bool) (_ []repositories.ProjectSummary, err error) {
+	ctx, span := tracer.Start(ctx, "ProjectService.ListProjects")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return s.repo.ListProjects(ctx, archived)
+}
+
+func (s *projectService) GetProject(ctx context.Context, id uint) (_ *repositories.ProjectSummary, err error) {
+	ctx, span := tracer.Start(ctx, "ProjectService.GetProject", trace.WithAttributes(attribute.Int("project.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return s.repo.GetProject(ctx, id)
+}
+
+func (s *projectService) CreateProject(ctx context.Context, username string, project *models.Project) (err error) {
+	ctx, span := tracer.Start(ctx, "ProjectService.CreateProject")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	project.Owner = username
+	if err := prepareProject(project); err != nil {
+		return err
+	}
+	return s.repo.CreateProject(ctx, project)
+}
+
+func (s *projectService) UpdateProject(ctx context.Context, username string, project *models.Project) (_ *repositories.ProjectSummary, err error) {
+	ctx, span := tracer.Start(ctx, "ProjectService.UpdateProject", trace.WithAttributes(attribute.Int("project.id", int(project.ID))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	existing, err := s.ownedProject(ctx, username, project.ID)
+	if err != nil {
+		return nil, err
+	}
+	project.Owner = existing.Owner
+	if err := prepareProject(project); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateProject(ctx, project); err != nil {
+		return nil, err
+	}
+	return s.repo.GetProject(ctx, project.ID)
+}
+
+func (s *projectService) ArchiveProject(ctx context.Context, username string, id uint, archived bool) (err error) {
+	ctx, span := tracer.Start(ctx, "ProjectService.ArchiveProject", trace.WithAttributes(
+		attribute.Int("project.id", int(id)),
+		attribute.Bool("project.archived", archived),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if _, err := s.ownedProject(ctx, username, id); err != nil {
+		return err
+	}
+	return s.repo.SetArchived(ctx, id, archived)
+}
+
+func (s *projectService) DeleteProject(ctx context.Context, username string, id uint) (err error) {
+	ctx, span := tracer.Start(ctx, "ProjectService.DeleteProject", trace.WithAttributes(attribute.Int("project.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if _, err := s.ownedProject(ctx, username, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteProject(ctx, id)
+}
+
+func (s *projectService) MoveTask(ctx context.Context, taskID uint, expectedVersion uint, projectID *uint) (_ *models.Task, err error) {
+	ctx, span := tracer.Start(ctx, "ProjectService.MoveTask", trace.WithAttributes(attribute.Int("task.id", int(taskID))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return s.repo.MoveTask(ctx, taskID, expectedVersion, projectID)
+}
+
+func (s *projectService) ownedProject(ctx context.Context, username string, id uint) (*repositories.ProjectSummary, error) {
+	project, err := s.repo.GetProject(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if project.Owner != username {
+		return nil, projectNotOwner(id)
+	}
+	return project, nil
+}
+
+// prepareProject merapikan nama dan warna (huruf kecil) lalu memvalidasi
+// project.
+func prepareProject(project *models.Project) error {
+	project.Name = strings.TrimSpace(project.Name)
+	project.Color = strings.ToLower(strings.TrimSpace(project.Color))
+	if err := utils.Validate.Struct(project); err != nil {
+		return utils.BindingError(err)
+	}
+	return nil
+}
+
+func projectNotOwner(id uint) error {
+	return apperrors.Forbidden("project_not_owner", fmt.Sprintf("Only the owner can change project %d", id)).WithParams(strconv.FormatUint(uint64(id), 10))
+}
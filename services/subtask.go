@@ -0,0 +1,153 @@
+// services/subtask.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/programmercintasunnah/go-todolist-ilcs/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func (s *taskService) GetSubtasks(ctx context.Context, id uint) (_ []models.Task, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.GetSubtasks", trace.WithAttributes(attribute.Int("task.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if _, err := s.repo.GetTaskByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.Subtasks(ctx, id)
+}
+
+// SetParent memindahkan task ke bawah parentID (nil: menjadi task tingkat
+// atas). Kedalaman seluruh subtree task tidak boleh melebihi
+// tasks.max_subtask_depth dan parent tidak boleh task itu sendiri atau
+// turunannya. Parent lama dan baru diperiksa untuk auto-complete.
+func (s *taskService) SetParent(ctx context.Context, id uint, expectedVersion uint, parentID *uint) (_ *models.Task, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.SetParent", trace.WithAttributes(attribute.Int("task.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	var task *models.Task
+	err = s.uow.Do(ctx, func(repos repositories.Repositories) error {
+		current, err := repos.Tasks.GetTaskByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if expectedVersion > 0 && current.Version != expectedVersion {
+			return repositories.VersionMismatch(id)
+		}
+
+		if parentID != nil {
+			height, err := repos.Tasks.SubtreeHeight(ctx, id, s.maxSubtaskDepth())
+			if err != nil {
+				return err
+			}
+			if err := s.checkParent(ctx, repos.Tasks, id, *parentID, height); err != nil {
+				return err
+			}
+		}
+
+		if err := repos.Tasks.SetParent(ctx, id, current.Version, parentID); err != nil {
+			return err
+		}
+		if err := completeParents(ctx, repos.Tasks, current.ParentID); err != nil {
+			return err
+		}
+		if err := completeParents(ctx, repos.Tasks, parentID); err != nil {
+			return err
+		}
+
+		task, err = repos.Tasks.GetTaskByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// maxSubtaskDepth mengembalikan tasks.max_subtask_depth yang berlaku.
+func (s *taskService) maxSubtaskDepth() int {
+	return int(s.maxDepth.Load())
+}
+
+// checkParent memastikan task id (0 untuk task baru) dengan subtree
+// setinggi height boleh ditaruh di bawah parentID: parent harus ada, bukan
+// id atau turunannya, dan kedalaman task terdalam tidak melebihi batas.
+// Kedalaman task tingkat atas adalah 0. Leluhur parentID dibaca paling
+// banyak batas+1 kali; rantai yang lebih panjang (batas diturunkan atau
+// data parent membentuk siklus) ditolak sebagai konflik.
+func (s *taskService) checkParent(ctx context.Context, tasks repositories.TaskRepository, id, parentID uint, height int) error {
+	max := s.maxSubtaskDepth()
+	depth := 0
+	for ancestor := &parentID; ancestor != nil; depth++ {
+		if depth > max {
+			return apperrors.Conflict("subtask_ancestors_too_deep", fmt.Sprintf("Task %d is nested more than %d levels deep", parentID, max)).
+				WithParams(strconv.FormatUint(uint64(parentID), 10), strconv.Itoa(max))
+		}
+		if id > 0 && *ancestor == id {
+			return apperrors.Conflict("subtask_cycle", fmt.Sprintf("Task %d cannot be a subtask of itself or its subtasks", id)).
+				WithParams(strconv.FormatUint(uint64(id), 10))
+		}
+		task, err := tasks.GetTaskByID(ctx, *ancestor)
+		if err != nil {
+			return err
+		}
+		ancestor = task.ParentID
+	}
+
+	if depth+height > max {
+		return apperrors.Validation("subtask_depth_exceeded", fmt.Sprintf("Subtasks cannot be nested more than %d levels deep", max)).
+			WithParams(strconv.Itoa(max))
+	}
+	return nil
+}
+
+// completeParents menyelesaikan parentID jika auto_complete aktif dan
+// semua subtask-nya sudah completed, lalu naik ke parent berikutnya.
+// Berhenti di parent yang belum boleh diselesaikan karena dependensi.
+func completeParents(ctx context.Context, tasks repositories.TaskRepository, parentID *uint) error {
+	for parentID != nil {
+		parent, err := tasks.GetTaskByID(ctx, *parentID)
+		if err != nil {
+			return err
+		}
+		if !parent.AutoComplete || parent.Status == "completed" {
+			return nil
+		}
+
+		subtasks, err := tasks.Subtasks(ctx, parent.ID)
+		if err != nil {
+			return err
+		}
+		progress := models.SubtaskProgress(subtasks)
+		if progress.Total == 0 || progress.Completed < progress.Total {
+			return nil
+		}
+
+		parent.Status = "completed"
+		if err := tasks.UpdateTask(ctx, parent); err != nil {
+			// Parent yang masih diblokir dependensinya tetap pending
+			if repositories.IsTaskBlocked(err) {
+				return nil
+			}
+			return err
+		}
+		parentID = parent.ParentID
+	}
+	return nil
+}
+
+// completeParentsOf menjalankan completeParents untuk parent task jika task
+// baru saja disimpan dengan status completed.
+func completeParentsOf(ctx context.Context, tasks repositories.TaskRepository, task *models.Task) error {
+	if task.Status != "completed" {
+		return nil
+	}
+	return completeParents(ctx, tasks, task.ParentID)
+}
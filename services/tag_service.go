@@ -0,0 +1,134 @@
+// services/tag_service.go
+package services
+
+import (
+	"context"
+	"slices"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/programmercintasunnah/go-todolist-ilcs/tracing"
+	"github.com/programmercintasunnah/go-todolist-ilcs/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TagService mengelola tag dan pemasangannya ke task. Nama tag selalu
+// dinormalisasi dengan models.NormalizeTagName.
+type TagService interface {
+	ListTags(ctx context.Context) ([]repositories.TagUsage, error)
+	GetTag(ctx context.Context, id uint) (*repositories.TagUsage, error)
+	CreateTag(ctx context.Context, tag *models.Tag) error
+	RenameTag(ctx context.Context, tag *models.Tag) error
+	// MergeTags menggabungkan tag sourceID ke targetID dan mengembalikan
+	// tag hasilnya.
+	MergeTags(ctx context.Context, sourceID, targetID uint) (*repositories.TagUsage, error)
+	DeleteTag(ctx context.Context, id uint) error
+	// AttachTags dan DetachTag menolak perubahan dengan
+	// ErrPreconditionFailed jika expectedVersion > 0 tidak lagi sama dengan
+	// versi task.
+	AttachTags(ctx context.Context, taskID uint, expectedVersion uint, names []string) (*models.Task, error)
+	DetachTag(ctx context.Context, taskID uint, expectedVersion uint, tagID uint) (*models.Task, error)
+}
+
+type tagService struct {
+	repo repositories.TagRepository
+}
+
+func NewTagService(repo repositories.TagRepository) TagService {
+	return &tagService{repo: repo}
+}
+
+func (s *tagService) ListTags(ctx context.Context) (_ []repositories.TagUsage, err error) {
+	ctx, span := tracer.Start(ctx, "TagService.ListTags")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return s.repo.ListTags(ctx)
+}
+
+func (s *tagService) GetTag(ctx context.Context, id uint) (_ *repositories.TagUsage, err error) {
+	ctx, span := tracer.Start(ctx, "TagService.GetTag", trace.WithAttributes(attribute.Int("tag.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return s.repo.GetTag(ctx, id)
+}
+
+func (s *tagService) CreateTag(ctx context.Context, tag *models.Tag) (err error) {
+	ctx, span := tracer.Start(ctx, "TagService.CreateTag")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if err := prepareTag(tag); err != nil {
+		return err
+	}
+	return s.repo.CreateTag(ctx, tag)
+}
+
+func (s *tagService) RenameTag(ctx context.Context, tag *models.Tag) (err error) {
+	ctx, span := tracer.Start(ctx, "TagService.RenameTag", trace.WithAttributes(attribute.Int("tag.id", int(tag.ID))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if err := prepareTag(tag); err != nil {
+		return err
+	}
+	return s.repo.RenameTag(ctx, tag)
+}
+
+func (s *tagService) MergeTags(ctx context.Context, sourceID, targetID uint) (_ *repositories.TagUsage, err error) {
+	ctx, span := tracer.Start(ctx, "TagService.MergeTags", trace.WithAttributes(
+		attribute.Int("tag.source_id", int(sourceID)),
+		attribute.Int("tag.target_id", int(targetID)),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if sourceID == targetID {
+		return nil, apperrors.Validation("tag_merge_same", "A tag cannot be merged into itself")
+	}
+	if err := s.repo.MergeTags(ctx, sourceID, targetID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetTag(ctx, targetID)
+}
+
+func (s *tagService) DeleteTag(ctx context.Context, id uint) (err error) {
+	ctx, span := tracer.Start(ctx, "TagService.DeleteTag", trace.WithAttributes(attribute.Int("tag.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return s.repo.DeleteTag(ctx, id)
+}
+
+func (s *tagService) AttachTags(ctx context.Context, taskID uint, expectedVersion uint, names []string) (_ *models.Task, err error) {
+	ctx, span := tracer.Start(ctx, "TagService.AttachTags", trace.WithAttributes(attribute.Int("task.id", int(taskID))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		tag := models.Tag{Name: name}
+		if err := prepareTag(&tag); err != nil {
+			return nil, err
+		}
+		if !slices.Contains(normalized, tag.Name) {
+			normalized = append(normalized, tag.Name)
+		}
+	}
+	return s.repo.AttachTags(ctx, taskID, expectedVersion, normalized)
+}
+
+func (s *tagService) DetachTag(ctx context.Context, taskID uint, expectedVersion uint, tagID uint) (_ *models.Task, err error) {
+	ctx, span := tracer.Start(ctx, "TagService.DetachTag", trace.WithAttributes(
+		attribute.Int("task.id", int(taskID)),
+		attribute.Int("tag.id", int(tagID)),
+	))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return s.repo.DetachTag(ctx, taskID, expectedVersion, tagID)
+}
+
+// prepareTag menormalisasi nama tag lalu memvalidasinya.
+func prepareTag(tag *models.Tag) error {
+	tag.Name = models.NormalizeTagName(tag.Name)
+	if err := utils.Validate.Struct(tag); err != nil {
+		return utils.BindingError(err)
+	}
+	return nil
+}
@@ -2,67 +2,288 @@
 package services
 
 import (
-	"strings"
+	"context"
+	"errors"
+	"sync/atomic"
 
+	"github.com/programmercintasunnah/go-todolist-ilcs/config"
+	"github.com/programmercintasunnah/go-todolist-ilcs/filter"
 	"github.com/programmercintasunnah/go-todolist-ilcs/models"
 	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/programmercintasunnah/go-todolist-ilcs/tracing"
+	"github.com/programmercintasunnah/go-todolist-ilcs/utils"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("github.com/programmercintasunnah/go-todolist-ilcs/services")
+
 type TaskService interface {
-	CreateTask(task *models.Task) error
-	GetTaskByID(id uint) (*models.Task, error)
-	GetAllTasks(filter map[string]interface{}, pagination repositories.Pagination, search string) ([]models.Task, int64, error)
-	UpdateTask(id uint, updatedTask *models.Task) error
-	DeleteTask(id uint) error
+	CreateTask(ctx context.Context, task *models.Task) error
+	GetTaskByID(ctx context.Context, id uint) (*models.Task, error)
+	GetAllTasks(ctx context.Context, where filter.Expr, pagination repositories.Pagination) (*repositories.TaskPage, error)
+	// UpdateTask, PatchTask dan DeleteTask menolak perubahan dengan
+	// ErrPreconditionFailed jika versi yang diharapkan (updatedTask.Version
+	// atau expectedVersion) tidak lagi sama; 0 berarti tanpa pemeriksaan.
+	UpdateTask(ctx context.Context, id uint, updatedTask *models.Task) error
+	PatchTask(ctx context.Context, id uint, expectedVersion uint, apply func(task *models.Task) error) (*models.Task, error)
+	DeleteTask(ctx context.Context, id uint, expectedVersion uint) error
+	// BulkTasks menjalankan banyak create/update/delete dalam satu transaksi;
+	// lihat TaskRepository.BulkWrite untuk arti atomic.
+	BulkTasks(ctx context.Context, ops []repositories.BulkOperation, atomic bool) ([]repositories.BulkResult, error)
+	// UpdateTasksByFilter dan DeleteTasksByFilter mengubah semua task yang
+	// cocok dengan filter GetAllTasks dan mengembalikan ID-nya; dryRun hanya
+	// menghitung tanpa mengubah apa pun.
+	UpdateTasksByFilter(ctx context.Context, where filter.Expr, changes repositories.TaskChanges, dryRun bool) ([]uint, error)
+	DeleteTasksByFilter(ctx context.Context, where filter.Expr, dryRun bool) ([]uint, error)
+	// SuggestTitles mengembalikan usulan title untuk type-ahead; lihat
+	// TaskRepository.SuggestTitles.
+	SuggestTitles(ctx context.Context, prefix string, limit int) ([]repositories.TitleSuggestion, error)
+	// GetSubtasks mengembalikan subtask langsung dari task id, urut ID.
+	GetSubtasks(ctx context.Context, id uint) ([]models.Task, error)
+	SetParent(ctx context.Context, id uint, expectedVersion uint, parentID *uint) (*models.Task, error)
 }
 
 type taskService struct {
-	repo   repositories.TaskRepository
-	logger *logrus.Logger
+	repo     repositories.TaskRepository
+	uow      repositories.UnitOfWork
+	logger   *logrus.Logger
+	maxDepth *atomic.Int64
 }
 
-func NewTaskService(repo repositories.TaskRepository, logger *logrus.Logger) TaskService {
-	return &taskService{
-		repo:   repo,
-		logger: logger,
+func NewTaskService(repo repositories.TaskRepository, uow repositories.UnitOfWork, watcher *config.Watcher, logger *logrus.Logger) TaskService {
+	s := &taskService{
+		repo:     repo,
+		uow:      uow,
+		logger:   logger,
+		maxDepth: new(atomic.Int64),
 	}
+
+	// Batas kedalaman subtask bisa diubah saat runtime lewat reload konfigurasi
+	watcher.Subscribe(func(cfg config.Config) {
+		s.maxDepth.Store(int64(cfg.Tasks.MaxSubtaskDepth))
+	})
+
+	return s
 }
 
-func (s *taskService) CreateTask(task *models.Task) error {
-	return s.repo.CreateTask(task)
+func (s *taskService) CreateTask(ctx context.Context, task *models.Task) (err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.CreateTask")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if err := utils.Validate.Struct(task); err != nil {
+		return utils.BindingError(err)
+	}
+	if task.ParentID == nil {
+		return s.repo.CreateTask(ctx, task)
+	}
+
+	// Subtask: kedalaman diperiksa dan parent bisa ikut selesai dalam
+	// transaksi yang sama
+	return s.uow.Do(ctx, func(repos repositories.Repositories) error {
+		if err := s.checkParent(ctx, repos.Tasks, 0, *task.ParentID, 0); err != nil {
+			return err
+		}
+		if err := repos.Tasks.CreateTask(ctx, task); err != nil {
+			return err
+		}
+		return completeParentsOf(ctx, repos.Tasks, task)
+	})
 }
 
-func (s *taskService) GetTaskByID(id uint) (*models.Task, error) {
-	return s.repo.GetTaskByID(id)
+func (s *taskService) GetTaskByID(ctx context.Context, id uint) (_ *models.Task, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.GetTaskByID", trace.WithAttributes(attribute.Int("task.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return s.repo.GetTaskByID(ctx, id)
 }
 
-func (s *taskService) GetAllTasks(filter map[string]interface{}, pagination repositories.Pagination, search string) ([]models.Task, int64, error) {
-	return s.repo.GetAllTasks(filter, pagination, search)
+func (s *taskService) GetAllTasks(ctx context.Context, where filter.Expr, pagination repositories.Pagination) (_ *repositories.TaskPage, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.GetAllTasks")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return s.repo.GetAllTasks(ctx, where, pagination)
 }
 
-func (s *taskService) UpdateTask(id uint, updatedTask *models.Task) error {
-	existingTask, err := s.repo.GetTaskByID(id)
-	if err != nil {
+func (s *taskService) SuggestTitles(ctx context.Context, prefix string, limit int) (_ []repositories.TitleSuggestion, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.SuggestTitles")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return s.repo.SuggestTitles(ctx, prefix, limit)
+}
+
+// UpdateTask mengganti seluruh field task yang bisa diubah (semantik PUT);
+// field kosong di updatedTask ikut disimpan kosong. Task dikunci selama
+// pemeriksaan versi dan penyimpanan.
+func (s *taskService) UpdateTask(ctx context.Context, id uint, updatedTask *models.Task) (err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.UpdateTask", trace.WithAttributes(attribute.Int("task.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	updatedTask.ID = id
+	if err := utils.Validate.Struct(updatedTask); err != nil {
+		return utils.BindingError(err)
+	}
+
+	return s.uow.Do(ctx, func(repos repositories.Repositories) error {
+		current, err := repos.Tasks.GetTaskByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if updatedTask.Version > 0 && updatedTask.Version != current.Version {
+			return repositories.VersionMismatch(id)
+		}
+		updatedTask.Version = current.Version
+		updatedTask.ParentID = current.ParentID
+		if err := repos.Tasks.UpdateTask(ctx, updatedTask); err != nil {
+			return err
+		}
+		return completeParentsOf(ctx, repos.Tasks, updatedTask)
+	})
+}
+
+// PatchTask membaca task, menjalankan apply untuk mengubahnya (mis. dari
+// JSON Patch) lalu memvalidasi dan menyimpan hasilnya dalam satu unit of
+// work, sehingga tidak ada perubahan lain di antara baca dan simpan.
+func (s *taskService) PatchTask(ctx context.Context, id uint, expectedVersion uint, apply func(task *models.Task) error) (_ *models.Task, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.PatchTask", trace.WithAttributes(attribute.Int("task.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	var patched *models.Task
+	err = s.uow.Do(ctx, func(repos repositories.Repositories) error {
+		task, err := repos.Tasks.GetTaskByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if expectedVersion > 0 && task.Version != expectedVersion {
+			return repositories.VersionMismatch(id)
+		}
+		version, parentID := task.Version, task.ParentID
+
+		if err := apply(task); err != nil {
+			return err
+		}
+		task.ID = id
+		task.Version = version
+		task.ParentID = parentID
+
+		if err := utils.Validate.Struct(task); err != nil {
+			return utils.BindingError(err)
+		}
+		if err := repos.Tasks.UpdateTask(ctx, task); err != nil {
+			return err
+		}
+		if err := completeParentsOf(ctx, repos.Tasks, task); err != nil {
+			return err
+		}
+
+		patched, err = repos.Tasks.GetTaskByID(ctx, id)
 		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return patched, nil
+}
+
+// DeleteTask menghapus task lalu memeriksa ulang parent-nya: subtask terakhir
+// yang belum selesai bisa saja yang dihapus.
+func (s *taskService) DeleteTask(ctx context.Context, id uint, expectedVersion uint) (err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.DeleteTask", trace.WithAttributes(attribute.Int("task.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return s.uow.Do(ctx, func(repos repositories.Repositories) error {
+		task, err := repos.Tasks.GetTaskByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := repos.Tasks.DeleteTask(ctx, id, expectedVersion); err != nil {
+			return err
+		}
+		return completeParents(ctx, repos.Tasks, task.ParentID)
+	})
+}
+
+// BulkTasks memvalidasi setiap task lebih dulu. Operasi yang tidak valid
+// tidak dikirim ke database: pada mode atomic seluruh request ditolak,
+// selain itu hanya operasi tersebut yang gagal.
+func (s *taskService) BulkTasks(ctx context.Context, ops []repositories.BulkOperation, atomic bool) (_ []repositories.BulkResult, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.BulkTasks", trace.WithAttributes(attribute.Int("bulk.size", len(ops))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	results := make([]repositories.BulkResult, len(ops))
+	valid := make([]repositories.BulkOperation, 0, len(ops))
+	indexes := make([]int, 0, len(ops))
+	for i, op := range ops {
+		if op.Op != repositories.BulkDelete {
+			if err := utils.Validate.Struct(op.Task); err != nil {
+				if atomic {
+					return nil, &repositories.BulkError{Index: i, Err: utils.BindingError(err)}
+				}
+				results[i].Err = utils.BindingError(err)
+				continue
+			}
+		}
+		valid = append(valid, op)
+		indexes = append(indexes, i)
 	}
 
-	if strings.TrimSpace(updatedTask.Title) != "" {
-		existingTask.Title = updatedTask.Title
+	// Parent diselesaikan di savepoint tiap operasi agar kegagalannya tidak
+	// membatalkan operasi lain pada mode per-item
+	written, err := s.repo.BulkWrite(ctx, valid, atomic, completeParentsOf)
+	if err != nil {
+		var bulkErr *repositories.BulkError
+		if errors.As(err, &bulkErr) {
+			return nil, &repositories.BulkError{Index: indexes[bulkErr.Index], Err: bulkErr.Err}
+		}
+		return nil, err
 	}
-	if strings.TrimSpace(updatedTask.Description) != "" {
-		existingTask.Description = updatedTask.Description
+	for j, result := range written {
+		results[indexes[j]] = result
 	}
-	if strings.TrimSpace(updatedTask.Status) != "" {
-		existingTask.Status = updatedTask.Status
+	return results, nil
+}
+
+func (s *taskService) UpdateTasksByFilter(ctx context.Context, where filter.Expr, changes repositories.TaskChanges, dryRun bool) (_ []uint, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.UpdateTasksByFilter")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if changes.Status != nil {
+		if err := utils.Validate.Var(*changes.Status, "oneof=pending completed"); err != nil {
+			return nil, utils.BindingError(err)
+		}
 	}
-	if !updatedTask.DueDate.IsZero() {
-		existingTask.DueDate = updatedTask.DueDate
+	if dryRun || changes.Status == nil || *changes.Status != "completed" {
+		return s.repo.UpdateByFilter(ctx, where, changes, dryRun)
 	}
 
-	return s.repo.UpdateTask(existingTask)
+	var ids []uint
+	err = s.uow.Do(ctx, func(repos repositories.Repositories) error {
+		var err error
+		if ids, err = repos.Tasks.UpdateByFilter(ctx, where, changes, false); err != nil {
+			return err
+		}
+		parentIDs, err := repos.Tasks.ParentIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+		for _, parentID := range parentIDs {
+			if err := completeParents(ctx, repos.Tasks, &parentID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
 }
 
-func (s *taskService) DeleteTask(id uint) error {
-	return s.repo.DeleteTask(id)
+func (s *taskService) DeleteTasksByFilter(ctx context.Context, where filter.Expr, dryRun bool) (_ []uint, err error) {
+	ctx, span := tracer.Start(ctx, "TaskService.DeleteTasksByFilter")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	return s.repo.DeleteByFilter(ctx, where, dryRun)
 }
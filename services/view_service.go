@@ -0,0 +1,202 @@
+// services/view_service.go
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/programmercintasunnah/go-todolist-ilcs/tracing"
+	"github.com/programmercintasunnah/go-todolist-ilcs/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ViewService mengelola saved view atas nama user yang login (username).
+// View yang tidak dimiliki dan tidak dibagikan ke user dilaporkan tidak
+// ada; hanya owner yang boleh mengubah atau menghapus view.
+type ViewService interface {
+	CreateView(ctx context.Context, username string, view *models.View) error
+	GetView(ctx context.Context, username string, id uint) (*models.View, error)
+	ListViews(ctx context.Context, username string) ([]models.View, error)
+	UpdateView(ctx context.Context, username string, view *models.View) error
+	DeleteView(ctx context.Context, username string, id uint) error
+	// SetDefaultView menjadikan view (milik sendiri atau yang dibagikan)
+	// sebagai view bawaan GET /api/tasks untuk username.
+	SetDefaultView(ctx context.Context, username string, id uint) error
+	// ClearDefaultView melepas view bawaan username jika view bawaannya id.
+	ClearDefaultView(ctx context.Context, username string, id uint) error
+	// DefaultView mengembalikan view bawaan username, atau nil.
+	DefaultView(ctx context.Context, username string) (*models.View, error)
+}
+
+type viewService struct {
+	repo repositories.ViewRepository
+}
+
+func NewViewService(repo repositories.ViewRepository) ViewService {
+	return &viewService{repo: repo}
+}
+
+func (s *viewService) CreateView(ctx context.Context, username string, view *models.View) (err error) {
+	ctx, span := tracer.Start(ctx, "ViewService.CreateView")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	view.Owner = username
+	if err := prepareView(view); err != nil {
+		return err
+	}
+	return s.repo.CreateView(ctx, view)
+}
+
+func (s *viewService) GetView(ctx context.Context, username string, id uint) (_ *models.View, err error) {
+	ctx, span := tracer.Start(ctx, "ViewService.GetView", trace.WithAttributes(attribute.Int("view.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	view, err := s.visibleView(ctx, username, id)
+	if err != nil {
+		return nil, err
+	}
+	defaultID, err := s.repo.DefaultViewID(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	view.IsDefault = view.ID == defaultID
+	return view, nil
+}
+
+func (s *viewService) ListViews(ctx context.Context, username string) (_ []models.View, err error) {
+	ctx, span := tracer.Start(ctx, "ViewService.ListViews")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	views, err := s.repo.ListViews(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	defaultID, err := s.repo.DefaultViewID(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	for i := range views {
+		views[i].IsDefault = views[i].ID == defaultID
+	}
+	return views, nil
+}
+
+func (s *viewService) UpdateView(ctx context.Context, username string, view *models.View) (err error) {
+	ctx, span := tracer.Start(ctx, "ViewService.UpdateView", trace.WithAttributes(attribute.Int("view.id", int(view.ID))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	existing, err := s.ownedView(ctx, username, view.ID)
+	if err != nil {
+		return err
+	}
+	view.Owner = existing.Owner
+	view.CreatedAt = existing.CreatedAt
+	if err := prepareView(view); err != nil {
+		return err
+	}
+	return s.repo.UpdateView(ctx, view)
+}
+
+func (s *viewService) DeleteView(ctx context.Context, username string, id uint) (err error) {
+	ctx, span := tracer.Start(ctx, "ViewService.DeleteView", trace.WithAttributes(attribute.Int("view.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if _, err := s.ownedView(ctx, username, id); err != nil {
+		return err
+	}
+	return s.repo.DeleteView(ctx, id)
+}
+
+func (s *viewService) SetDefaultView(ctx context.Context, username string, id uint) (err error) {
+	ctx, span := tracer.Start(ctx, "ViewService.SetDefaultView", trace.WithAttributes(attribute.Int("view.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if _, err := s.visibleView(ctx, username, id); err != nil {
+		return err
+	}
+	return s.repo.SetDefaultView(ctx, username, id)
+}
+
+func (s *viewService) ClearDefaultView(ctx context.Context, username string, id uint) (err error) {
+	ctx, span := tracer.Start(ctx, "ViewService.ClearDefaultView", trace.WithAttributes(attribute.Int("view.id", int(id))))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if _, err := s.visibleView(ctx, username, id); err != nil {
+		return err
+	}
+	defaultID, err := s.repo.DefaultViewID(ctx, username)
+	if err != nil || defaultID != id {
+		return err
+	}
+	return s.repo.SetDefaultView(ctx, username, 0)
+}
+
+func (s *viewService) DefaultView(ctx context.Context, username string) (_ *models.View, err error) {
+	ctx, span := tracer.Start(ctx, "ViewService.DefaultView")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	id, err := s.repo.DefaultViewID(ctx, username)
+	if err != nil || id == 0 {
+		return nil, err
+	}
+	view, err := s.repo.GetView(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	view.IsDefault = true
+	return view, nil
+}
+
+// visibleView membaca view yang boleh dipakai username. View milik orang
+// lain yang tidak dibagikan dilaporkan tidak ada agar keberadaannya tidak
+// bocor.
+func (s *viewService) visibleView(ctx context.Context, username string, id uint) (*models.View, error) {
+	view, err := s.repo.GetView(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !view.VisibleTo(username) {
+		return nil, repositories.ViewNotFound(id)
+	}
+	return view, nil
+}
+
+func (s *viewService) ownedView(ctx context.Context, username string, id uint) (*models.View, error) {
+	view, err := s.visibleView(ctx, username, id)
+	if err != nil {
+		return nil, err
+	}
+	if view.Owner != username {
+		return nil, viewNotOwner(id)
+	}
+	return view, nil
+}
+
+// prepareView merapikan SharedWith (tanpa spasi, duplikat dan owner) lalu
+// memvalidasi view.
+func prepareView(view *models.View) error {
+	shared := make([]string, 0, len(view.SharedWith))
+	for _, username := range view.SharedWith {
+		username = strings.TrimSpace(username)
+		if username != view.Owner && !slices.Contains(shared, username) {
+			shared = append(shared, username)
+		}
+	}
+	view.SharedWith = shared
+
+	if err := utils.Validate.Struct(view); err != nil {
+		return utils.BindingError(err)
+	}
+	return nil
+}
+
+func viewNotOwner(id uint) error {
+	return apperrors.Forbidden("view_not_owner", fmt.Sprintf("Only the owner can change view %d", id)).WithParams(strconv.FormatUint(uint64(id), 10))
+}
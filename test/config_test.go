@@ -0,0 +1,147 @@
+// tests/config_test.go
+package tests
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/config"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func setRequiredConfigEnv(t *testing.T) {
+	t.Setenv("DB_USER", "user")
+	t.Setenv("DB_NAME", "todo")
+	t.Setenv("JWT_SECRET", "secret")
+}
+
+func TestLoadConfigLayering(t *testing.T) {
+	setRequiredConfigEnv(t)
+	path := writeConfigFile(t, "config.yaml", `
+server:
+  port: 9000
+  read_timeout: 5s
+redis:
+  db: 3
+`)
+	t.Setenv("SERVER_PORT", "9100")
+
+	cfg, err := config.Load([]string{"-config", path, "-redis-db", "4"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 9100, cfg.Server.Port, "env overrides file")
+	assert.Equal(t, 5*time.Second, cfg.Server.ReadTimeout, "file overrides default")
+	assert.Equal(t, 15*time.Second, cfg.Server.WriteTimeout, "default kept")
+	assert.Equal(t, 4, cfg.Redis.DB, "flag overrides file")
+}
+
+func TestLoadConfigTOML(t *testing.T) {
+	setRequiredConfigEnv(t)
+	path := writeConfigFile(t, "config.toml", `
+[database]
+max_open_conns = 50
+conn_max_lifetime = "1m"
+`)
+
+	cfg, err := config.Load([]string{"-config", path})
+	require.NoError(t, err)
+
+	assert.Equal(t, 50, cfg.Database.MaxOpenConns)
+	assert.Equal(t, time.Minute, cfg.Database.ConnMaxLifetime)
+}
+
+func TestLoadConfigAggregatesErrors(t *testing.T) {
+	t.Setenv("SERVER_READ_TIMEOUT", "soon")
+	t.Setenv("REDIS_DB", "x")
+
+	_, err := config.Load(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "server.read_timeout (env SERVER_READ_TIMEOUT)")
+	assert.Contains(t, err.Error(), "redis.db (env REDIS_DB)")
+
+	t.Setenv("SERVER_READ_TIMEOUT", "")
+	t.Setenv("REDIS_DB", "")
+
+	_, err = config.Load(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "database.user: required")
+	assert.Contains(t, err.Error(), "jwt.secret: required")
+}
+
+func TestLoadConfigSecretsFromFiles(t *testing.T) {
+	setRequiredConfigEnv(t)
+	t.Setenv("DB_PASSWORD", "from-env")
+	t.Setenv("DB_PASSWORD_FILE", writeConfigFile(t, "db_password", "from-file\n"))
+
+	key, err := config.GenerateSecretsKey()
+	require.NoError(t, err)
+	rawKey, err := config.DecodeSecretsKey(key)
+	require.NoError(t, err)
+
+	secretsPath := filepath.Join(t.TempDir(), "secrets.enc")
+	f, err := os.Create(secretsPath)
+	require.NoError(t, err)
+	require.NoError(t, config.EncryptSecrets(f, map[string]string{"JWT_SECRET": "from-encrypted-file"}, rawKey))
+	require.NoError(t, f.Close())
+	t.Setenv("SECRETS_FILE", secretsPath)
+	t.Setenv("SECRETS_KEY", key)
+
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", cfg.Database.Password)
+	assert.Equal(t, "from-encrypted-file", cfg.JWT.Secret)
+
+	otherKey, err := config.GenerateSecretsKey()
+	require.NoError(t, err)
+	t.Setenv("SECRETS_KEY", otherKey)
+	_, err = config.Load(nil)
+	assert.ErrorContains(t, err, "decryption failed")
+}
+
+func TestLoadConfigRejectsWeakJWTSecretInProduction(t *testing.T) {
+	setRequiredConfigEnv(t)
+	t.Setenv("APP_ENV", "production")
+
+	t.Setenv("JWT_SECRET", "mz_ilcs_go")
+	_, err := config.Load(nil)
+	assert.ErrorContains(t, err, "known default value")
+	assert.ErrorContains(t, err, "at least 32 characters")
+
+	t.Setenv("JWT_SECRET", "7f3c1e9a0b5d4c2e8f6a1b3d5c7e9f0a2b4d")
+	_, err = config.Load(nil)
+	assert.NoError(t, err)
+}
+
+func TestWatcherReloadsOnlyReloadableFields(t *testing.T) {
+	initial := config.Default()
+	next := initial
+	next.Cache.TaskTTL = time.Minute
+	next.Server.Port = 9999
+	var loadErr error
+
+	watcher := config.NewWatcher(initial, func() (config.Config, error) { return next, loadErr }, logrus.New())
+
+	var seen []time.Duration
+	watcher.Subscribe(func(cfg config.Config) { seen = append(seen, cfg.Cache.TaskTTL) })
+
+	require.NoError(t, watcher.Reload())
+	assert.Equal(t, []time.Duration{10 * time.Minute, time.Minute}, seen)
+	assert.Equal(t, time.Minute, watcher.Current().Cache.TaskTTL)
+	assert.Equal(t, 8080, watcher.Current().Server.Port, "non-reloadable field needs restart")
+
+	loadErr = errors.New("invalid")
+	next.Cache.TaskTTL = time.Hour
+	assert.Error(t, watcher.Reload())
+	assert.Equal(t, time.Minute, watcher.Current().Cache.TaskTTL, "rejected reload keeps current config")
+}
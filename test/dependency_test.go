@@ -0,0 +1,186 @@
+// tests/dependency_test.go
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/config"
+	"github.com/programmercintasunnah/go-todolist-ilcs/filter"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/programmercintasunnah/go-todolist-ilcs/services"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDependencyService(t *testing.T) {
+	f := setupRepositories(t)
+	ctx := context.Background()
+	svc := services.NewDependencyService(repositories.NewDependencyRepository(f.repo))
+	tasks := newSubtaskService(f, 3)
+
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	var ids []uint
+	for i := range 4 {
+		task := models.Task{Title: "Step", Status: "pending", DueDate: due, Priority: min(i, 1)}
+		require.NoError(t, f.repo.CreateTask(ctx, &task))
+		ids = append(ids, task.ID)
+	}
+
+	// 1 <- 2 <- 3: task 3 diblokir 2, task 2 diblokir 1
+	require.NoError(t, svc.LinkTasks(ctx, ids[1], ids[0]))
+	require.NoError(t, svc.LinkTasks(ctx, ids[2], ids[1]))
+	assert.ErrorIs(t, svc.LinkTasks(ctx, ids[2], ids[1]), apperrors.ErrConflict)
+	assert.ErrorIs(t, svc.LinkTasks(ctx, ids[0], ids[2]), apperrors.ErrConflict)
+	assert.ErrorIs(t, svc.LinkTasks(ctx, ids[0], ids[0]), apperrors.ErrConflict)
+	assert.ErrorIs(t, svc.LinkTasks(ctx, ids[0], 99), apperrors.ErrNotFound)
+
+	graph, err := svc.GetGraph(ctx, ids[1])
+	require.NoError(t, err)
+	assert.Equal(t, []uint{ids[0]}, graph.BlockedBy)
+	assert.Equal(t, []uint{ids[2]}, graph.Blocking)
+	assert.Len(t, graph.Nodes, 3)
+	assert.Equal(t, []models.Dependency{{TaskID: ids[1], BlockerID: ids[0]}, {TaskID: ids[2], BlockerID: ids[1]}}, graph.Edges)
+
+	// Task 2 belum boleh selesai selama task 1 pending, lewat jalur mana pun
+	err = tasks.UpdateTask(ctx, ids[1], &models.Task{Title: "Step", Status: "completed", DueDate: due})
+	assert.ErrorIs(t, err, apperrors.ErrConflict)
+	assert.True(t, repositories.IsTaskBlocked(err))
+	// Filter tanpa task 1 ditolak karena blocker task 2 tidak ikut selesai
+	where, err := filter.Parse("priority:1")
+	require.NoError(t, err)
+	completed := "completed"
+	_, err = tasks.UpdateTasksByFilter(ctx, where, repositories.TaskChanges{Status: &completed}, false)
+	assert.ErrorIs(t, err, apperrors.ErrConflict)
+	results, err := tasks.BulkTasks(ctx, []repositories.BulkOperation{
+		{Op: repositories.BulkUpdate, Task: models.Task{ID: ids[0], Title: "Step", Status: "completed", DueDate: due}},
+		{Op: repositories.BulkUpdate, Task: models.Task{ID: ids[2], Title: "Step", Status: "completed", DueDate: due}},
+	}, false)
+	require.NoError(t, err)
+	assert.NoError(t, results[0].Err)
+	assert.ErrorIs(t, results[1].Err, apperrors.ErrConflict)
+
+	// Task 1 sudah completed sehingga task 2 boleh diselesaikan
+	require.NoError(t, tasks.UpdateTask(ctx, ids[1], &models.Task{Title: "Step", Status: "completed", DueDate: due}))
+
+	require.NoError(t, svc.UnlinkTasks(ctx, ids[2], ids[1]))
+	assert.ErrorIs(t, svc.UnlinkTasks(ctx, ids[2], ids[1]), apperrors.ErrNotFound)
+	graph, err = svc.GetGraph(ctx, ids[2])
+	require.NoError(t, err)
+	assert.Empty(t, graph.BlockedBy)
+	assert.Empty(t, graph.Edges)
+
+	// Dependensi milik task yang terhapus diabaikan
+	require.NoError(t, svc.LinkTasks(ctx, ids[3], ids[2]))
+	require.NoError(t, tasks.DeleteTask(ctx, ids[2], 0))
+	require.NoError(t, tasks.UpdateTask(ctx, ids[3], &models.Task{Title: "Step", Status: "completed", DueDate: due}))
+	graph, err = svc.GetGraph(ctx, ids[3])
+	require.NoError(t, err)
+	assert.Empty(t, graph.BlockedBy)
+}
+
+func TestUpdateByFilterCompletesBlockers(t *testing.T) {
+	f := setupRepositories(t)
+	ctx := context.Background()
+	svc := services.NewDependencyService(repositories.NewDependencyRepository(f.repo))
+
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	var ids []uint
+	for range 3 {
+		task := models.Task{Title: "Step", Status: "pending", DueDate: due}
+		require.NoError(t, f.repo.CreateTask(ctx, &task))
+		ids = append(ids, task.ID)
+	}
+	// Task 1 diblokir task 3 yang ID-nya lebih besar, sehingga diperiksa
+	// sebelum blocker-nya diubah
+	require.NoError(t, svc.LinkTasks(ctx, ids[0], ids[2]))
+	require.NoError(t, svc.LinkTasks(ctx, ids[1], ids[0]))
+
+	where, err := filter.Parse("status:pending")
+	require.NoError(t, err)
+	completed := "completed"
+	updated, err := f.repo.UpdateByFilter(ctx, where, repositories.TaskChanges{Status: &completed}, false)
+	require.NoError(t, err)
+	assert.Equal(t, ids, updated)
+	for _, id := range ids {
+		task, err := f.repo.GetTaskByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "completed", task.Status)
+	}
+}
+
+func TestDependencyEnforcementReload(t *testing.T) {
+	f := setupRepositories(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: f.redis.Addr()})
+	t.Cleanup(func() { redisClient.Close() })
+	cfg := config.Default()
+	next := cfg
+	next.Tasks.EnforceDependencies = false
+	logger := logrus.New()
+	watcher := config.NewWatcher(cfg, func() (config.Config, error) { return next, nil }, logger)
+	repo := repositories.NewTaskRepository(f.db, repositories.SQLite, redisClient, watcher, logger)
+	svc := services.NewDependencyService(repositories.NewDependencyRepository(repo))
+	ctx := context.Background()
+
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	blocker := models.Task{Title: "Blocker", Status: "pending", DueDate: due}
+	require.NoError(t, repo.CreateTask(ctx, &blocker))
+	task := models.Task{Title: "Blocked", Status: "pending", DueDate: due}
+	require.NoError(t, repo.CreateTask(ctx, &task))
+	require.NoError(t, svc.LinkTasks(ctx, task.ID, blocker.ID))
+
+	task.Status = "completed"
+	assert.ErrorIs(t, repo.UpdateTask(ctx, &task), apperrors.ErrConflict)
+	require.NoError(t, watcher.Reload())
+	assert.NoError(t, repo.UpdateTask(ctx, &task))
+}
+
+func TestDependencyEndpoints(t *testing.T) {
+	router, _ := setupRepoRouter(t)
+
+	for range 3 {
+		task := gin.H{"title": "Step", "status": "pending", "due_date": "2026-11-01"}
+		require.Equal(t, http.StatusCreated, sendJSON(router, "POST", "/api/tasks", task, nil).Code)
+	}
+
+	w := sendJSON(router, "POST", "/api/tasks/2/dependencies", gin.H{"blocked_by": 1}, nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Equal(t, http.StatusCreated, sendJSON(router, "POST", "/api/tasks/3/dependencies", gin.H{"blocked_by": 2}, nil).Code)
+	w = sendJSON(router, "POST", "/api/tasks/1/dependencies", gin.H{"blocked_by": 3}, nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "dependency_cycle", decodeProblem(t, w).Code)
+	assert.Equal(t, http.StatusBadRequest, sendJSON(router, "POST", "/api/tasks/1/dependencies", gin.H{}, nil).Code)
+
+	var graph struct {
+		TaskID    uint                `json:"task_id"`
+		BlockedBy []uint              `json:"blocked_by"`
+		Blocking  []uint              `json:"blocking"`
+		Nodes     []struct{ ID uint } `json:"nodes"`
+		Edges     []models.Dependency `json:"edges"`
+	}
+	w = sendJSON(router, "GET", "/api/tasks/3/dependencies", nil, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &graph))
+	assert.Equal(t, []uint{2}, graph.BlockedBy)
+	assert.Equal(t, []uint{}, graph.Blocking)
+	assert.Len(t, graph.Nodes, 3)
+	assert.Len(t, graph.Edges, 2)
+	assert.Equal(t, http.StatusNotFound, sendJSON(router, "GET", "/api/tasks/9/dependencies", nil, nil).Code)
+
+	w = sendJSON(router, "PUT", "/api/tasks/2", gin.H{"title": "Step", "status": "completed", "due_date": "2026-11-01"}, nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "task_blocked", decodeProblem(t, w).Code)
+
+	assert.Equal(t, http.StatusOK, sendJSON(router, "DELETE", "/api/tasks/2/dependencies/1", nil, nil).Code)
+	assert.Equal(t, http.StatusNotFound, sendJSON(router, "DELETE", "/api/tasks/2/dependencies/1", nil, nil).Code)
+	assert.Equal(t, http.StatusBadRequest, sendJSON(router, "DELETE", "/api/tasks/2/dependencies/x", nil, nil).Code)
+	assert.Equal(t, http.StatusOK, sendJSON(router, "PUT", "/api/tasks/2", gin.H{"title": "Step", "status": "completed", "due_date": "2026-11-01"}, nil).Code)
+}
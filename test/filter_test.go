@@ -0,0 +1,142 @@
+// tests/filter_test.go
+package tests
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/filter"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	cases := map[string]string{
+		`status:pending due<2026-11-01 -title:draft`:  `status:"pending" due_date<2026-11-01 -title:"draft"`,
+		`priority>=2 OR status:completed`:             `priority>=2 OR status:"completed"`,
+		`(priority:3 OR due<=2026-11-01) "two words"`: `(priority:3 OR due_date<2026-11-02) "two words"`,
+		`due:2026-11-01`:                `due_date>=2026-11-01 due_date<2026-11-02`,
+		`-(title:"a \"b\"" AND report)`: `-(title:"a \"b\"" "report")`,
+		`tag:Infra OR tag:"On Call"`:    `tag:"infra" OR tag:"on call"`,
+		`project:none OR project:3`:     `project_id:0 OR project_id:3`,
+		`-archived:TRUE`:                `-archived:true`,
+	}
+	for input, want := range cases {
+		e, err := filter.Parse(input)
+		if assert.NoError(t, err, input) {
+			assert.Equal(t, want, e.String(), input)
+		}
+	}
+
+	e, err := filter.Parse("   ")
+	assert.NoError(t, err)
+	assert.Nil(t, e)
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	cases := map[string]int{
+		`owner:bob`:               0,
+		`tag<infra`:               4,
+		`project:x`:               8,
+		`archived:maybe`:          9,
+		`status:done`:             7,
+		`priority>9`:              9,
+		`due<tomorrow`:            4,
+		`title>abc`:               6,
+		`(status:pending`:         0,
+		`status:pending )`:        15,
+		`"unterminated`:           0,
+		`- status:pending`:        1,
+		`status: pending`:         7,
+		`OR status:pending`:       0,
+		`((((((((((((((((((((((x`: 20,
+		`---------------------x`:  20,
+	}
+	for input, pos := range cases {
+		_, err := filter.Parse(input)
+		var filterErr *filter.Error
+		if assert.ErrorAs(t, err, &filterErr, input) {
+			assert.Equal(t, pos, filterErr.Pos, "%s: %s", input, filterErr.Msg)
+		}
+	}
+}
+
+func filterFixtures() []models.Task {
+	due := time.Date(2026, 11, 1, 9, 0, 0, 0, time.UTC)
+	return []models.Task{
+		{Title: "Draft report", Description: "Quarterly numbers", Status: "pending", DueDate: due, Priority: 1},
+		{Title: "Deploy infra", Description: "100% rollout", Status: "pending", DueDate: due.AddDate(0, 0, 1), Priority: 3},
+		{Title: "Review", Status: "completed", DueDate: due.AddDate(0, 0, -1), Priority: 2},
+		{Title: "under_score", Description: "draft notes", Status: "completed", DueDate: due, Priority: 0},
+	}
+}
+
+// TestFilterSQLMatchesInMemory memastikan SQL hasil kompilasi dan
+// filter.Match memberi hasil yang sama untuk data yang sama.
+func TestFilterSQLMatchesInMemory(t *testing.T) {
+	f := setupRepositories(t)
+	ctx := context.Background()
+
+	var tasks []models.Task
+	for _, task := range filterFixtures() {
+		require.NoError(t, f.repo.CreateTask(ctx, &task))
+		tasks = append(tasks, task)
+	}
+
+	queries := map[string][]uint{
+		`status:pending`:                     {1, 2},
+		`draft`:                              {1, 4},
+		`-title:draft`:                       {2, 3, 4},
+		`due:2026-11-01`:                     {1, 4},
+		`due<=2026-11-01 priority>0`:         {1, 3},
+		`due>2026-11-01 OR priority:2`:       {2, 3},
+		`-(status:completed OR priority>=3)`: {1},
+		`description:"100%"`:                 {2},
+		`"_"`:                                {4},
+		`title:report OR (description:notes -status:pending)`: {1, 4},
+	}
+	for q, want := range queries {
+		e, err := filter.Parse(q)
+		require.NoError(t, err, q)
+
+		page, err := f.repo.GetAllTasks(ctx, e, repositories.Pagination{Page: 1, Limit: 10})
+		require.NoError(t, err, q)
+		assert.Equal(t, want, taskIDs(page.Tasks), "sql: %s", q)
+
+		var matched []uint
+		for _, task := range tasks {
+			if filter.Match(e, task) {
+				matched = append(matched, task.ID)
+			}
+		}
+		assert.Equal(t, want, matched, "memory: %s", q)
+	}
+}
+
+func TestGetAllTasksFilterQuery(t *testing.T) {
+	var tasks []models.Task
+	for i, task := range filterFixtures() {
+		task.ID = uint(i + 1)
+		tasks = append(tasks, task)
+	}
+	router := SetupRouter(tasks...)
+
+	assert.Equal(t, []uint{2}, listTaskIDs(t, router, "/api/tasks?q="+url.QueryEscape("status:pending -draft")))
+	// Parameter lama digabung dengan q memakai AND
+	assert.Equal(t, []uint{3}, listTaskIDs(t, router, "/api/tasks?status=completed&q="+url.QueryEscape("priority>0")))
+
+	w, _ := getList(t, router, "/api/tasks?q="+url.QueryEscape("status:pending owner:bob"))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	problem := decodeProblem(t, w)
+	assert.Equal(t, "invalid_filter", problem.Code)
+	assert.Equal(t, `Invalid q: unknown field "owner" at position 15`, problem.Detail)
+
+	w, _ = getList(t, router, "/api/tasks?status=done")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "invalid_filter", decodeProblem(t, w).Code)
+}
@@ -0,0 +1,157 @@
+// tests/fuzzy_test.go
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/filter"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWordSimilarity(t *testing.T) {
+	// Contoh dari dokumentasi pg_trgm
+	assert.InDelta(t, 0.8, filter.WordSimilarity("word", "two words"), 1e-9)
+	assert.InDelta(t, 1, filter.WordSimilarity("Team Meeting", "team meeting"), 1e-9)
+	assert.GreaterOrEqual(t, filter.WordSimilarity("meetnig", "Team meeting"), filter.FuzzyThreshold)
+	assert.Zero(t, filter.WordSimilarity("invoice", "Team meeting"))
+	assert.Zero(t, filter.WordSimilarity("--", "Team meeting"))
+}
+
+func fuzzyFixtures() []models.Task {
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	return []models.Task{
+		{Title: "Quarterly report", Status: "pending", DueDate: due},
+		{Title: "Team meeting", Status: "pending", DueDate: due},
+		{Title: "Quarterly planning", Status: "completed", DueDate: due},
+		{Title: "Budget review", Status: "pending", DueDate: due},
+	}
+}
+
+func TestFuzzySearchInProcess(t *testing.T) {
+	f := setupRepositories(t)
+	ctx := context.Background()
+	for _, task := range fuzzyFixtures() {
+		require.NoError(t, f.repo.CreateTask(ctx, &task))
+	}
+
+	relevance := []repositories.SortField{{Field: repositories.RelevanceSort, Desc: true}}
+	page, err := f.repo.GetAllTasks(ctx, filter.Fuzzy{Value: "quartrly"}, repositories.Pagination{Page: 1, Limit: 10, Sort: relevance})
+	require.NoError(t, err)
+	assert.Equal(t, []uint{1, 3}, taskIDs(page.Tasks))
+	assert.Equal(t, int64(2), page.Total)
+	assert.InDelta(t, 0.58, page.Hits[1].Rank, 0.01)
+
+	// Sisa filter tetap dijalankan di database
+	where := filter.Join(filter.Fuzzy{Value: "quartrly"}, filter.Comparison{Field: "status", Op: filter.OpEq, Value: "completed"})
+	page, err = f.repo.GetAllTasks(ctx, where, repositories.Pagination{Page: 1, Limit: 10, Sort: relevance})
+	require.NoError(t, err)
+	assert.Equal(t, []uint{3}, taskIDs(page.Tasks))
+
+	page, err = f.repo.GetAllTasks(ctx, filter.Fuzzy{Value: "quartrly"}, repositories.Pagination{Page: 2, Limit: 1, Sort: relevance})
+	require.NoError(t, err)
+	assert.Equal(t, []uint{3}, taskIDs(page.Tasks))
+	assert.True(t, page.HasPrev)
+	assert.False(t, page.HasNext)
+
+	page, err = f.repo.GetAllTasks(ctx, filter.Fuzzy{Value: "invoice"}, repositories.Pagination{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, page.Tasks)
+
+	// Tanpa pg_trgm, Fuzzy hanya didukung di puncak filter
+	_, err = f.repo.GetAllTasks(ctx, filter.Or{Exprs: []filter.Expr{filter.Fuzzy{Value: "quartrly"}, filter.Text{Value: "budget"}}}, repositories.Pagination{Page: 1, Limit: 10})
+	assert.ErrorIs(t, err, apperrors.ErrValidation)
+}
+
+func TestSuggestTitlesRepository(t *testing.T) {
+	f := setupRepositories(t)
+	ctx := context.Background()
+	for _, task := range fuzzyFixtures() {
+		require.NoError(t, f.repo.CreateTask(ctx, &task))
+	}
+
+	titles := func(prefix string, limit int) []string {
+		suggestions, err := f.repo.SuggestTitles(ctx, prefix, limit)
+		require.NoError(t, err)
+		var titles []string
+		for _, s := range suggestions {
+			titles = append(titles, s.Title)
+		}
+		return titles
+	}
+
+	assert.Equal(t, []string{"Quarterly report", "Quarterly planning"}, titles("Qua", 5))
+	assert.Equal(t, []string{"Quarterly report"}, titles("qua", 1))
+	// Kecocokan awal kata mendahului kecocokan fuzzy ("re" dari report)
+	assert.Equal(t, []string{"Budget review", "Quarterly report"}, titles("rev", 5))
+	// Wildcard LIKE di prefix dicari apa adanya
+	assert.Empty(t, titles("%", 5))
+	// Prefix yang salah ketik dilengkapi dari kemiripan
+	assert.Equal(t, []string{"Team meeting"}, titles("meetnig", 5))
+}
+
+func TestGetAllTasksFuzzy(t *testing.T) {
+	var tasks []models.Task
+	for i, task := range fuzzyFixtures() {
+		task.ID = uint(i + 1)
+		tasks = append(tasks, task)
+	}
+	router := SetupRouter(tasks...)
+
+	assert.Equal(t, []uint{1, 3}, listTaskIDs(t, router, "/api/tasks?fuzzy=quartrly"))
+	assert.Equal(t, []uint{3}, listTaskIDs(t, router, "/api/tasks?fuzzy=quartrly&status=completed"))
+
+	w, _ := getList(t, router, "/api/tasks?fuzzy=quartrly&full_text=report")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	problem := decodeProblem(t, w)
+	require.Len(t, problem.Errors, 1)
+	assert.Equal(t, "fuzzy", problem.Errors[0].Field)
+	assert.Equal(t, "excluded_with", problem.Errors[0].Rule)
+}
+
+func TestSuggestTitles(t *testing.T) {
+	var tasks []models.Task
+	for i, task := range fuzzyFixtures() {
+		task.ID = uint(i + 1)
+		tasks = append(tasks, task)
+	}
+	router := SetupRouter(tasks...)
+
+	suggest := func(url string) (*httptest.ResponseRecorder, []uint) {
+		req, _ := http.NewRequest("GET", url, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var response struct {
+			Suggestions []struct {
+				ID    uint    `json:"id"`
+				Score float64 `json:"score"`
+			} `json:"suggestions"`
+		}
+		var ids []uint
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			for _, s := range response.Suggestions {
+				ids = append(ids, s.ID)
+			}
+		}
+		return w, ids
+	}
+
+	_, ids := suggest("/api/tasks/suggest?prefix=qua")
+	assert.Equal(t, []uint{1, 3}, ids)
+	_, ids = suggest("/api/tasks/suggest?prefix=meetnig&limit=1")
+	assert.Equal(t, []uint{2}, ids)
+
+	w, _ := suggest("/api/tasks/suggest")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w, _ = suggest("/api/tasks/suggest?prefix=qua&limit=50")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
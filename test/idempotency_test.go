@@ -0,0 +1,134 @@
+// tests/idempotency_test.go
+package tests
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/programmercintasunnah/go-todolist-ilcs/config"
+	"github.com/programmercintasunnah/go-todolist-ilcs/controllers"
+	"github.com/programmercintasunnah/go-todolist-ilcs/middlewares"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/programmercintasunnah/go-todolist-ilcs/services"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryIdempotencyStore menggantikan Redis di test.
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]repositories.IdempotencyRecord
+}
+
+func (s *memoryIdempotencyStore) Reserve(_ context.Context, key, fingerprint string, _ time.Duration) (*repositories.IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.records[key]; ok {
+		return &existing, false, nil
+	}
+	s.records[key] = repositories.IdempotencyRecord{Fingerprint: fingerprint}
+	return nil, true, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(_ context.Context, key string, record repositories.IdempotencyRecord, _ time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record.Completed = true
+	s.records[key] = record
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+func setupIdempotentRouter(mockService *services.MockTaskService) *gin.Engine {
+	logger := logrus.New()
+	store := &memoryIdempotencyStore{records: make(map[string]repositories.IdempotencyRecord)}
+	watcher := config.NewWatcher(config.Default(), nil, logger)
+
+	router := gin.New()
+	router.Use(middlewares.Locale(), middlewares.ErrorHandler(logger), middlewares.Idempotency(store, watcher, logger))
+	taskController := controllers.NewTaskController(mockService, &services.MockViewService{}, "test-cursor-secret", logger)
+	router.POST("/api/tasks", taskController.CreateTask)
+	router.POST("/api/tasks/bulk", taskController.BulkTasks)
+	return router
+}
+
+func postWithKey(router *gin.Engine, key, body string) *httptest.ResponseRecorder {
+	return postPathWithKey(router, "/api/tasks", key, body)
+}
+
+func postPathWithKey(router *gin.Engine, path, key, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("POST", path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", key)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotencyKeyReplaysCreate(t *testing.T) {
+	mockService := &services.MockTaskService{}
+	router := setupIdempotentRouter(mockService)
+	body := `{"title": "Buy milk", "status": "pending", "due_date": "2026-11-01"}`
+
+	first := postWithKey(router, "retry-1", body)
+	assert.Equal(t, http.StatusCreated, first.Code)
+
+	second := postWithKey(router, "retry-1", body)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, "true", second.Header().Get("Idempotent-Replayed"))
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Len(t, mockService.Tasks, 1, "retry must not create a duplicate")
+
+	other := postWithKey(router, "retry-1", `{"title": "Buy bread", "status": "pending", "due_date": "2026-11-01"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, other.Code)
+	assert.Equal(t, "idempotency_key_reused", decodeProblem(t, other).Code)
+}
+
+func TestIdempotencyKeyNotStoredOnError(t *testing.T) {
+	mockService := &services.MockTaskService{}
+	router := setupIdempotentRouter(mockService)
+
+	w := postWithKey(router, "retry-2", `{"title": "Buy milk"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Request yang sudah diperbaiki boleh memakai key yang sama
+	w = postWithKey(router, "retry-2", `{"title": "Buy milk", "status": "pending", "due_date": "2026-11-01"}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Len(t, mockService.Tasks, 1)
+}
+
+func TestIdempotencyKeyNotStoredOnAtomicBulkFailure(t *testing.T) {
+	mockService := &services.MockTaskService{Tasks: []models.Task{existingTask()}}
+	router := setupIdempotentRouter(mockService)
+	body := `{"operations": [
+		{"op": "create", "title": "New", "status": "pending", "due_date": "2026-11-05"},
+		{"op": "delete", "id": 99}
+	]}`
+
+	w := postPathWithKey(router, "/api/tasks/bulk", "bulk-1", body)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Len(t, mockService.Tasks, 1)
+
+	// Setelah task 99 ada, retry dengan key yang sama dijalankan ulang, bukan
+	// memutar ulang 404
+	missing := existingTask()
+	missing.ID = 99
+	mockService.Tasks = append(mockService.Tasks, missing)
+	w = postPathWithKey(router, "/api/tasks/bulk", "bulk-1", body)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Idempotent-Replayed"))
+	assert.Len(t, mockService.Tasks, 2)
+}
@@ -0,0 +1,201 @@
+// tests/project_test.go
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/filter"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/programmercintasunnah/go-todolist-ilcs/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectService(t *testing.T) {
+	f := setupRepositories(t)
+	ctx := context.Background()
+	svc := services.NewProjectService(repositories.NewProjectRepository(f.repo))
+
+	work := models.Project{Name: " Work ", Color: "#1E90FF"}
+	require.NoError(t, svc.CreateProject(ctx, "alice", &work))
+	assert.Equal(t, "Work", work.Name)
+	assert.Equal(t, "#1e90ff", work.Color)
+	home := models.Project{Name: "Home"}
+	require.NoError(t, svc.CreateProject(ctx, "alice", &home))
+
+	// Nama unik per owner, bukan global
+	assert.ErrorIs(t, svc.CreateProject(ctx, "alice", &models.Project{Name: "Work"}), apperrors.ErrConflict)
+	require.NoError(t, svc.CreateProject(ctx, "bob", &models.Project{Name: "Work"}))
+	assert.ErrorIs(t, svc.CreateProject(ctx, "alice", &models.Project{Name: "Red", Color: "red"}), apperrors.ErrValidation)
+
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	var ids []uint
+	for i, projectID := range []*uint{&work.ID, &work.ID, nil} {
+		task := models.Task{Title: "Task", Status: []string{"pending", "completed", "pending"}[i], DueDate: due, ProjectID: projectID}
+		require.NoError(t, f.repo.CreateTask(ctx, &task))
+		ids = append(ids, task.ID)
+	}
+	err := f.repo.CreateTask(ctx, &models.Task{Title: "Lost", Status: "pending", DueDate: due, ProjectID: new(uint)})
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+	project, err := svc.GetProject(ctx, work.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, project.Pending)
+	assert.Equal(t, 1, project.Completed)
+
+	where := func(q string) []uint {
+		e, err := filter.Parse(q)
+		require.NoError(t, err)
+		page, err := f.repo.GetAllTasks(ctx, e, repositories.Pagination{Page: 1, Limit: 10})
+		require.NoError(t, err)
+		return taskIDs(page.Tasks)
+	}
+	assert.Equal(t, []uint{ids[0], ids[1]}, where("project:1"))
+	assert.Equal(t, []uint{ids[2]}, where("project:none"))
+
+	// Hanya owner yang boleh mengarsipkan
+	assert.ErrorIs(t, svc.ArchiveProject(ctx, "bob", work.ID, true), apperrors.ErrForbidden)
+
+	cached, err := f.repo.GetTaskByID(ctx, ids[0])
+	require.NoError(t, err)
+	require.NoError(t, svc.ArchiveProject(ctx, "alice", work.ID, true))
+	archived, err := f.repo.GetTaskByID(ctx, ids[0])
+	require.NoError(t, err)
+	assert.True(t, archived.Archived)
+	assert.Equal(t, cached.Version+1, archived.Version)
+	assert.Equal(t, []uint{ids[2]}, where("archived:false"))
+
+	projects, err := svc.ListProjects(ctx, new(bool))
+	require.NoError(t, err)
+	require.Len(t, projects, 2)
+	assert.Equal(t, "Home", projects[0].Name)
+
+	// Project terarsip tidak menerima task baru
+	err = f.repo.CreateTask(ctx, &models.Task{Title: "Late", Status: "pending", DueDate: due, ProjectID: &work.ID})
+	assert.ErrorIs(t, err, apperrors.ErrConflict)
+	_, err = svc.MoveTask(ctx, ids[2], 0, &work.ID)
+	assert.ErrorIs(t, err, apperrors.ErrConflict)
+
+	// Task yang keluar dari project terarsip tampil kembali
+	moved, err := svc.MoveTask(ctx, ids[0], archived.Version, &home.ID)
+	require.NoError(t, err)
+	assert.Equal(t, home.ID, *moved.ProjectID)
+	assert.False(t, moved.Archived)
+	assert.Equal(t, archived.Version+1, moved.Version)
+	_, err = svc.MoveTask(ctx, ids[0], archived.Version, nil)
+	assert.ErrorIs(t, err, apperrors.ErrPreconditionFailed)
+
+	require.NoError(t, svc.ArchiveProject(ctx, "alice", work.ID, false))
+	assert.Equal(t, []uint{ids[0], ids[1], ids[2]}, where("archived:false"))
+
+	assert.ErrorIs(t, svc.DeleteProject(ctx, "alice", work.ID), apperrors.ErrConflict)
+	_, err = svc.MoveTask(ctx, ids[1], 0, nil)
+	require.NoError(t, err)
+	require.NoError(t, svc.DeleteProject(ctx, "alice", work.ID))
+	_, err = svc.GetProject(ctx, work.ID)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+}
+
+func TestGetAllTasksArchived(t *testing.T) {
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	project := uint(1)
+	router := SetupRouter(
+		models.Task{ID: 1, Title: "Open", Status: "pending", DueDate: due},
+		models.Task{ID: 2, Title: "Shelved", Status: "pending", DueDate: due, ProjectID: &project, Archived: true},
+	)
+
+	assert.Equal(t, []uint{1}, listTaskIDs(t, router, "/api/tasks"))
+	assert.Equal(t, []uint{2}, listTaskIDs(t, router, "/api/tasks?archived=true"))
+	assert.Equal(t, []uint{1, 2}, listTaskIDs(t, router, "/api/tasks?archived=all"))
+	assert.Equal(t, []uint{2}, listTaskIDs(t, router, "/api/tasks?archived=all&q=project:1"))
+
+	w, _ := getList(t, router, "/api/tasks?archived=maybe")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "archived", decodeProblem(t, w).Errors[0].Field)
+}
+
+func TestProjectEndpoints(t *testing.T) {
+	router, _ := setupRepoRouter(t)
+	alice := http.Header{"X-Test-User": {"alice"}}
+
+	w := sendJSON(router, "POST", "/api/projects", gin.H{"name": "Work", "color": "#1e90ff"}, alice)
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/api/projects/1", w.Header().Get("Location"))
+	w = sendJSON(router, "POST", "/api/projects", gin.H{"name": "Paint", "color": "blue"}, alice)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "hexcolor", decodeProblem(t, w).Errors[0].Rule)
+
+	task := gin.H{"title": "Deploy", "status": "pending", "due_date": "2026-11-01"}
+	w = sendJSON(router, "POST", "/api/projects/1/tasks", task, alice)
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/api/tasks/1", w.Header().Get("Location"))
+	assert.Equal(t, http.StatusNotFound, sendJSON(router, "POST", "/api/projects/9/tasks", task, alice).Code)
+	assert.Equal(t, http.StatusBadRequest, sendJSON(router, "GET", "/api/projects/abc/tasks", nil, alice).Code)
+
+	// Task kedua dibuat di project Home lalu dipindahkan ke Work
+	w = sendJSON(router, "POST", "/api/projects", gin.H{"name": "Home"}, alice)
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Equal(t, http.StatusCreated, sendJSON(router, "POST", "/api/projects/2/tasks", task, alice).Code)
+	w = sendJSON(router, "PUT", "/api/tasks/2/project", gin.H{"project_id": 1}, alice)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"2-2"`, w.Header().Get("ETag"))
+	assert.Equal(t, http.StatusNotFound, sendJSON(router, "PUT", "/api/tasks/2/project", gin.H{"project_id": 9}, alice).Code)
+	w = sendJSON(router, "PUT", "/api/tasks/-1/project", gin.H{"project_id": 1}, alice)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "invalid_task_id", decodeProblem(t, w).Code)
+
+	w = sendJSON(router, "GET", "/api/projects/1", nil, alice)
+	var project struct {
+		Name           string `json:"name"`
+		Owner          string `json:"owner"`
+		PendingTasks   int    `json:"pending_tasks"`
+		CompletedTasks int    `json:"completed_tasks"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &project))
+	assert.Equal(t, "alice", project.Owner)
+	assert.Equal(t, 2, project.PendingTasks)
+	assert.Equal(t, 0, project.CompletedTasks)
+	assert.Equal(t, []uint{1, 2}, listTaskIDs(t, router, "/api/projects/1/tasks"))
+	assert.Empty(t, listTaskIDs(t, router, "/api/projects/2/tasks"))
+
+	// Arsip menyembunyikan task dari daftar bawaan, tidak dari route project
+	assert.Equal(t, http.StatusForbidden, sendJSON(router, "PUT", "/api/projects/1/archive", nil, http.Header{"X-Test-User": {"bob"}}).Code)
+	require.Equal(t, http.StatusNoContent, sendJSON(router, "PUT", "/api/projects/1/archive", nil, alice).Code)
+	assert.Empty(t, listTaskIDs(t, router, "/api/tasks"))
+	assert.Equal(t, []uint{1, 2}, listTaskIDs(t, router, "/api/projects/1/tasks"))
+	assert.Equal(t, []uint{1, 2}, listTaskIDs(t, router, "/api/tasks?archived=true"))
+	assert.Equal(t, http.StatusConflict, sendJSON(router, "POST", "/api/projects/1/tasks", task, alice).Code)
+
+	var list struct {
+		Projects []struct {
+			Name string `json:"name"`
+		} `json:"projects"`
+	}
+	w = sendJSON(router, "GET", "/api/projects", nil, alice)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Projects, 1)
+	assert.Equal(t, "Home", list.Projects[0].Name)
+	w = sendJSON(router, "GET", "/api/projects?archived=all", nil, alice)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list.Projects, 2)
+
+	require.Equal(t, http.StatusNoContent, sendJSON(router, "DELETE", "/api/projects/1/archive", nil, alice).Code)
+	assert.Equal(t, []uint{1, 2}, listTaskIDs(t, router, "/api/tasks"))
+
+	w = sendJSON(router, "PUT", "/api/projects/1", gin.H{"name": "Home"}, alice)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "project_name_taken", decodeProblem(t, w).Code)
+	assert.Equal(t, http.StatusOK, sendJSON(router, "PUT", "/api/projects/1", gin.H{"name": "Office"}, alice).Code)
+
+	w = sendJSON(router, "DELETE", "/api/projects/1", nil, alice)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "project_not_empty", decodeProblem(t, w).Code)
+	assert.Equal(t, http.StatusOK, sendJSON(router, "DELETE", "/api/projects/2", nil, alice).Code)
+}
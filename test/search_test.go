@@ -0,0 +1,161 @@
+// tests/search_test.go
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/filter"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSearch(t *testing.T) {
+	e, err := filter.ParseSearch(`Reports "quarterly  review" -draft e-mail`)
+	require.NoError(t, err)
+	assert.Equal(t, `search(reports "quarterly review" -draft "e mail")`, e.String())
+
+	e, err = filter.ParseSearch("  ")
+	require.NoError(t, err)
+	assert.Nil(t, e)
+
+	for input, msg := range map[string]string{
+		`"quarterly review`: "unterminated phrase",
+		"-draft":            "search needs at least one term that is not excluded",
+	} {
+		_, err := filter.ParseSearch(input)
+		var filterErr *filter.Error
+		require.ErrorAs(t, err, &filterErr, input)
+		assert.Equal(t, msg, filterErr.Msg, input)
+	}
+}
+
+func searchFixtures() []models.Task {
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	return []models.Task{
+		{Title: "Quarterly report", Description: "Send the <b>final</b> numbers", Status: "pending", DueDate: due},
+		{Title: "Team meeting", Description: "Discuss the report draft", Status: "pending", DueDate: due},
+		{Title: "Write reports", Description: "Reporting for the board", Status: "completed", DueDate: due},
+		{Title: "Quarterly planning", Description: "Review the roadmap", Status: "pending", DueDate: due},
+	}
+}
+
+func TestFullTextSearch(t *testing.T) {
+	f := setupRepositories(t)
+	ctx := context.Background()
+	for _, task := range searchFixtures() {
+		require.NoError(t, f.repo.CreateTask(ctx, &task))
+	}
+
+	search := func(q string) *repositories.TaskPage {
+		where, err := filter.ParseSearch(q)
+		require.NoError(t, err)
+		page, err := f.repo.GetAllTasks(ctx, where, repositories.Pagination{
+			Page:  1,
+			Limit: 10,
+			Sort:  []repositories.SortField{{Field: repositories.RelevanceSort, Desc: true}},
+		})
+		require.NoError(t, err)
+		return page
+	}
+
+	// Stemming: "reports" juga menemukan "report" dan "reporting"
+	page := search("reports")
+	assert.ElementsMatch(t, []uint{1, 2, 3}, taskIDs(page.Tasks))
+	// Kata di title lebih relevan daripada di description
+	assert.Equal(t, uint(2), page.Tasks[len(page.Tasks)-1].ID)
+	assert.Greater(t, page.Hits[1].Rank, page.Hits[2].Rank)
+
+	assert.Equal(t, []uint{1}, taskIDs(search(`"quarterly report"`).Tasks))
+	assert.ElementsMatch(t, []uint{1, 3}, taskIDs(search("report -draft").Tasks))
+	assert.Empty(t, search("invoice").Tasks)
+
+	// Cuplikan menandai kata yang cocok dan meng-escape isi task
+	hit := search("numbers").Hits[1]
+	assert.Contains(t, hit.Snippet, "<mark>numbers</mark>")
+	assert.Contains(t, hit.Snippet, "&lt;b&gt;final&lt;/b&gt;")
+
+	// Indeks mengikuti perubahan task
+	task, err := f.repo.GetTaskByID(ctx, 4)
+	require.NoError(t, err)
+	task.Title = "Budget forecast"
+	require.NoError(t, f.repo.UpdateTask(ctx, task))
+	assert.Empty(t, search("planning").Tasks)
+	assert.Equal(t, []uint{4}, taskIDs(search("forecast").Tasks))
+
+	require.NoError(t, f.repo.DeleteTask(ctx, 1, 0))
+	assert.ElementsMatch(t, []uint{2, 3}, taskIDs(search("report").Tasks))
+}
+
+func TestFullTextSearchMatchesInMemory(t *testing.T) {
+	f := setupRepositories(t)
+	ctx := context.Background()
+	tasks := searchFixtures()
+	for i := range tasks {
+		require.NoError(t, f.repo.CreateTask(ctx, &tasks[i]))
+	}
+
+	for _, q := range []string{"report", "reporting -board", `"quarterly planning"`, "quarterly -report", "review"} {
+		where, err := filter.ParseSearch(q)
+		require.NoError(t, err)
+		page, err := f.repo.GetAllTasks(ctx, where, repositories.Pagination{Page: 1, Limit: 10})
+		require.NoError(t, err)
+
+		var matched []uint
+		for _, task := range tasks {
+			if filter.Match(where, task) {
+				matched = append(matched, task.ID)
+			}
+		}
+		assert.Equal(t, matched, taskIDs(page.Tasks), q)
+	}
+}
+
+func TestGetAllTasksSearch(t *testing.T) {
+	var tasks []models.Task
+	for i, task := range searchFixtures() {
+		task.ID = uint(i + 1)
+		tasks = append(tasks, task)
+	}
+	router := SetupRouter(tasks...)
+
+	// Tanpa sort, hasil pencarian diurutkan berdasarkan relevansi
+	w, _ := getList(t, router, "/api/tasks?limit=1&full_text="+url.QueryEscape("report -draft"))
+	require.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Tasks []struct {
+			ID   uint     `json:"id"`
+			Rank *float64 `json:"rank"`
+		} `json:"tasks"`
+		Pagination map[string]interface{} `json:"pagination"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Tasks, 1)
+	assert.Equal(t, uint(3), response.Tasks[0].ID)
+	assert.NotNil(t, response.Tasks[0].Rank)
+	// Urutan relevansi tidak mendukung cursor walau masih ada halaman berikut
+	assert.Equal(t, true, response.Pagination["has_next"])
+	assert.NotContains(t, response.Pagination, "next_cursor")
+
+	assert.Equal(t, []uint{3, 1}, listTaskIDs(t, router, "/api/tasks?full_text=report&sort=status:asc,relevance:desc&q="+url.QueryEscape("-draft")))
+
+	w, _ = getList(t, router, "/api/tasks?sort=relevance")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// search tetap pencarian substring tanpa peringkat
+	assert.Equal(t, []uint{1, 3}, listTaskIDs(t, router, "/api/tasks?search=REPO&q="+url.QueryEscape("-draft")))
+	w, _ = getList(t, router, "/api/tasks?search=report&sort=relevance")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w, _ = getList(t, router, "/api/tasks?full_text=-draft")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	problem := decodeProblem(t, w)
+	assert.Equal(t, "invalid_filter", problem.Code)
+	assert.Equal(t, "Invalid full_text: search needs at least one term that is not excluded at position 0", problem.Detail)
+}
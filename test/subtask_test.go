@@ -0,0 +1,326 @@
+// tests/subtask_test.go
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/config"
+	"github.com/programmercintasunnah/go-todolist-ilcs/filter"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/programmercintasunnah/go-todolist-ilcs/services"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSubtaskService(f repoFixture, maxDepth int) services.TaskService {
+	cfg := config.Default()
+	cfg.Tasks.MaxSubtaskDepth = maxDepth
+	logger := logrus.New()
+	return services.NewTaskService(f.repo, f.uow, config.NewWatcher(cfg, nil, logger), logger)
+}
+
+func TestSubtaskService(t *testing.T) {
+	f := setupRepositories(t)
+	ctx := context.Background()
+	svc := newSubtaskService(f, 2)
+
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	create := func(parentID *uint, autoComplete bool) models.Task {
+		task := models.Task{Title: "Step", Status: "pending", DueDate: due, ParentID: parentID, AutoComplete: autoComplete}
+		require.NoError(t, svc.CreateTask(ctx, &task))
+		return task
+	}
+	root := create(nil, true)
+	child := create(&root.ID, true)
+	grandchild := create(&child.ID, false)
+	sibling := create(&root.ID, false)
+
+	// Kedalaman maksimal 2: cucu tidak boleh punya anak lagi
+	err := svc.CreateTask(ctx, &models.Task{Title: "Too deep", Status: "pending", DueDate: due, ParentID: &grandchild.ID})
+	assert.ErrorIs(t, err, apperrors.ErrValidation)
+	err = svc.CreateTask(ctx, &models.Task{Title: "Orphan", Status: "pending", DueDate: due, ParentID: new(uint)})
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+	// Subtree child setinggi 1 tidak muat di bawah sibling (kedalaman 1)
+	_, err = svc.SetParent(ctx, child.ID, 0, &sibling.ID)
+	assert.ErrorIs(t, err, apperrors.ErrValidation)
+	_, err = svc.SetParent(ctx, root.ID, 0, &grandchild.ID)
+	assert.ErrorIs(t, err, apperrors.ErrConflict)
+	_, err = svc.SetParent(ctx, root.ID, 0, &root.ID)
+	assert.ErrorIs(t, err, apperrors.ErrConflict)
+
+	subtasks, err := svc.GetSubtasks(ctx, root.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{child.ID, sibling.ID}, taskIDs(subtasks))
+	assert.Equal(t, models.Progress{Completed: 0, Total: 2, Percent: 0}, models.SubtaskProgress(subtasks))
+
+	// Menyelesaikan cucu menyelesaikan child (auto_complete), root belum
+	// karena sibling masih pending
+	_, err = svc.PatchTask(ctx, grandchild.ID, 0, func(task *models.Task) error {
+		task.Status = "completed"
+		return nil
+	})
+	require.NoError(t, err)
+	got, err := svc.GetTaskByID(ctx, child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "completed", got.Status)
+	got, err = svc.GetTaskByID(ctx, root.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "pending", got.Status)
+
+	// Melepas sibling yang pending membuat semua subtask root completed
+	moved, err := svc.SetParent(ctx, sibling.ID, sibling.Version, nil)
+	require.NoError(t, err)
+	assert.Nil(t, moved.ParentID)
+	assert.Equal(t, sibling.Version+1, moved.Version)
+	got, err = svc.GetTaskByID(ctx, root.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "completed", got.Status)
+
+	// Menghapus parent menjadikan subtask-nya task tingkat atas
+	require.NoError(t, svc.DeleteTask(ctx, child.ID, 0))
+	got, err = svc.GetTaskByID(ctx, grandchild.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.ParentID)
+}
+
+func TestSubtaskParentChainIsBounded(t *testing.T) {
+	f := setupRepositories(t)
+	ctx := context.Background()
+	svc := newSubtaskService(f, 2)
+
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	create := func(parentID *uint) models.Task {
+		task := models.Task{Title: "Step", Status: "pending", DueDate: due, ParentID: parentID}
+		require.NoError(t, f.repo.CreateTask(ctx, &task))
+		return task
+	}
+
+	// Rantai yang ditulis langsung ke repository lebih dalam dari batas
+	root := create(nil)
+	child := create(&root.ID)
+	grandchild := create(&child.ID)
+	deepest := create(&grandchild.ID)
+	err := svc.CreateTask(ctx, &models.Task{Title: "Too deep", Status: "pending", DueDate: due, ParentID: &deepest.ID})
+	var appErr *apperrors.Error
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "subtask_ancestors_too_deep", appErr.Code)
+
+	// Parent yang saling menunjuk tidak membuat pemeriksaan berputar terus
+	first, second := create(nil), create(nil)
+	require.NoError(t, f.repo.SetParent(ctx, first.ID, 0, &second.ID))
+	require.NoError(t, f.repo.SetParent(ctx, second.ID, 0, &first.ID))
+	err = svc.CreateTask(ctx, &models.Task{Title: "Loop", Status: "pending", DueDate: due, ParentID: &first.ID})
+	assert.ErrorIs(t, err, apperrors.ErrConflict)
+}
+
+func TestDeleteLastOpenSubtaskCompletesParent(t *testing.T) {
+	f := setupRepositories(t)
+	ctx := context.Background()
+	svc := newSubtaskService(f, 2)
+
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	create := func(parentID *uint, status string) models.Task {
+		task := models.Task{Title: "Step", Status: status, DueDate: due, ParentID: parentID, AutoComplete: parentID == nil}
+		require.NoError(t, svc.CreateTask(ctx, &task))
+		return task
+	}
+	parent := create(nil, "pending")
+	create(&parent.ID, "completed")
+	open := create(&parent.ID, "pending")
+
+	require.NoError(t, svc.DeleteTask(ctx, open.ID, open.Version))
+	got, err := svc.GetTaskByID(ctx, parent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "completed", got.Status)
+	_, err = svc.GetTaskByID(ctx, open.ID)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+	// Versi yang tidak cocok tetap ditolak dan task tidak terhapus
+	assert.ErrorIs(t, svc.DeleteTask(ctx, got.ID, got.Version+1), apperrors.ErrPreconditionFailed)
+	_, err = svc.GetTaskByID(ctx, got.ID)
+	assert.NoError(t, err)
+}
+
+func TestBulkWritesCompleteParents(t *testing.T) {
+	f := setupRepositories(t)
+	ctx := context.Background()
+	svc := newSubtaskService(f, 2)
+
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	create := func(parentID *uint, priority int) models.Task {
+		task := models.Task{Title: "Step", Status: "pending", DueDate: due, ParentID: parentID, AutoComplete: parentID == nil, Priority: priority}
+		require.NoError(t, svc.CreateTask(ctx, &task))
+		return task
+	}
+	status := func(id uint) string {
+		task, err := svc.GetTaskByID(ctx, id)
+		require.NoError(t, err)
+		return task.Status
+	}
+
+	// Dua subtask dari parent yang sama selesai lewat satu filter
+	first := create(nil, 0)
+	create(&first.ID, 1)
+	create(&first.ID, 1)
+	where, err := filter.Parse("priority:1")
+	require.NoError(t, err)
+	completed := "completed"
+	ids, err := svc.UpdateTasksByFilter(ctx, where, repositories.TaskChanges{Status: &completed}, false)
+	require.NoError(t, err)
+	assert.Len(t, ids, 2)
+	assert.Equal(t, "completed", status(first.ID))
+
+	// Pada mode per-item operasi yang gagal tidak membatalkan parent yang
+	// diselesaikan operasi lain
+	second := create(nil, 0)
+	child := create(&second.ID, 2)
+	results, err := svc.BulkTasks(ctx, []repositories.BulkOperation{
+		{Op: repositories.BulkUpdate, Task: models.Task{ID: child.ID, Title: "Step", Status: "completed", DueDate: due, Priority: 2}},
+		{Op: repositories.BulkUpdate, Task: models.Task{ID: 999, Title: "Missing", Status: "completed", DueDate: due}},
+	}, false)
+	require.NoError(t, err)
+	assert.NoError(t, results[0].Err)
+	assert.ErrorIs(t, results[1].Err, apperrors.ErrNotFound)
+	assert.Equal(t, "completed", status(second.ID))
+}
+
+func TestSubtaskDepthReload(t *testing.T) {
+	f := setupRepositories(t)
+	ctx := context.Background()
+	cfg := config.Default()
+	cfg.Tasks.MaxSubtaskDepth = 1
+	next := cfg
+	next.Tasks.MaxSubtaskDepth = 2
+	logger := logrus.New()
+	watcher := config.NewWatcher(cfg, func() (config.Config, error) { return next, nil }, logger)
+	svc := services.NewTaskService(f.repo, f.uow, watcher, logger)
+
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	root := models.Task{Title: "Root", Status: "pending", DueDate: due}
+	require.NoError(t, svc.CreateTask(ctx, &root))
+	child := models.Task{Title: "Child", Status: "pending", DueDate: due, ParentID: &root.ID}
+	require.NoError(t, svc.CreateTask(ctx, &child))
+	grandchild := models.Task{Title: "Grandchild", Status: "pending", DueDate: due, ParentID: &child.ID}
+	assert.ErrorIs(t, svc.CreateTask(ctx, &grandchild), apperrors.ErrValidation)
+
+	require.NoError(t, watcher.Reload())
+	assert.NoError(t, svc.CreateTask(ctx, &grandchild))
+}
+
+func TestSubtaskEndpoints(t *testing.T) {
+	router, _ := setupRepoRouter(t)
+
+	task := gin.H{"title": "Release", "status": "pending", "due_date": "2026-11-01", "auto_complete": true}
+	require.Equal(t, http.StatusCreated, sendJSON(router, "POST", "/api/tasks", task, nil).Code)
+	for _, status := range []string{"completed", "pending"} {
+		subtask := gin.H{"title": "Step", "status": status, "due_date": "2026-11-01", "parent_id": 1}
+		require.Equal(t, http.StatusCreated, sendJSON(router, "POST", "/api/tasks", subtask, nil).Code)
+	}
+
+	var body struct {
+		Subtasks []struct {
+			ID       uint   `json:"id"`
+			ParentID uint   `json:"parent_id"`
+			ETag     string `json:"etag"`
+		} `json:"subtasks"`
+		Progress models.Progress `json:"progress"`
+	}
+	w := sendJSON(router, "GET", "/api/tasks/1/subtasks", nil, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body.Subtasks, 2)
+	assert.Equal(t, uint(1), body.Subtasks[0].ParentID)
+	assert.Equal(t, `"2-1"`, body.Subtasks[0].ETag)
+	assert.Equal(t, models.Progress{Completed: 1, Total: 2, Percent: 50}, body.Progress)
+	assert.Equal(t, http.StatusNotFound, sendJSON(router, "GET", "/api/tasks/9/subtasks", nil, nil).Code)
+	w = sendJSON(router, "GET", "/api/tasks/-1/subtasks", nil, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "invalid_task_id", decodeProblem(t, w).Code)
+
+	w = sendJSON(router, "PUT", "/api/tasks/1/parent", gin.H{"parent_id": 3}, nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "subtask_cycle", decodeProblem(t, w).Code)
+	assert.Equal(t, http.StatusPreconditionFailed, sendJSON(router, "PUT", "/api/tasks/3/parent", gin.H{"parent_id": nil}, http.Header{"If-Match": {`"3-9"`}}).Code)
+
+	// Subtask terakhir selesai lewat PUT: parent ikut completed
+	update := gin.H{"title": "Step", "status": "completed", "due_date": "2026-11-01"}
+	require.Equal(t, http.StatusOK, sendJSON(router, "PUT", "/api/tasks/3", update, http.Header{"If-Match": {`"3-1"`}}).Code)
+	var parent struct {
+		Task models.Task `json:"task"`
+	}
+	w = sendJSON(router, "GET", "/api/tasks/1", nil, nil)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &parent.Task))
+	assert.Equal(t, "completed", parent.Task.Status)
+	assert.True(t, parent.Task.AutoComplete)
+
+	w = sendJSON(router, "PUT", "/api/tasks/3/parent", gin.H{"parent_id": nil}, http.Header{"If-Match": {`"3-2"`}})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &parent))
+	assert.Nil(t, parent.Task.ParentID)
+	assert.Equal(t, `"3-3"`, w.Header().Get("ETag"))
+}
+
+func TestChecklistEndpoints(t *testing.T) {
+	router, _ := setupRepoRouter(t)
+
+	task := gin.H{"title": "Pack", "status": "pending", "due_date": "2026-11-01"}
+	w := sendJSON(router, "POST", "/api/tasks", task, nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"checklist":[]`)
+
+	var added struct {
+		Item models.ChecklistItem `json:"item"`
+		Task models.Task          `json:"task"`
+	}
+	w = sendJSON(router, "POST", "/api/tasks/1/checklist", gin.H{"text": "  Passport "}, http.Header{"If-Match": {`"1-1"`}})
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, `"1-2"`, w.Header().Get("ETag"))
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &added))
+	assert.Equal(t, "Passport", added.Item.Text)
+	require.Equal(t, http.StatusCreated, sendJSON(router, "POST", "/api/tasks/1/checklist", gin.H{"text": "Charger", "done": true}, nil).Code)
+
+	assert.Equal(t, http.StatusPreconditionFailed, sendJSON(router, "POST", "/api/tasks/1/checklist", gin.H{"text": "Late"}, http.Header{"If-Match": {`"1-1"`}}).Code)
+	assert.Equal(t, http.StatusBadRequest, sendJSON(router, "POST", "/api/tasks/1/checklist", gin.H{"text": ""}, nil).Code)
+	assert.Equal(t, http.StatusNotFound, sendJSON(router, "POST", "/api/tasks/9/checklist", gin.H{"text": "Ghost"}, nil).Code)
+	w = sendJSON(router, "POST", "/api/tasks/-1/checklist", gin.H{"text": "Ghost"}, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "invalid_task_id", decodeProblem(t, w).Code)
+
+	w = sendJSON(router, "PUT", fmt.Sprintf("/api/tasks/1/checklist/%d", added.Item.ID), gin.H{"text": "Passport", "done": true}, http.Header{"If-Match": {`"1-3"`}})
+	require.Equal(t, http.StatusOK, w.Code)
+	var updated struct {
+		Task models.Task `json:"task"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+	require.Len(t, updated.Task.Checklist, 2)
+	assert.Equal(t, "Passport", updated.Task.Checklist[0].Text)
+	assert.True(t, updated.Task.Checklist[0].Done)
+	assert.Equal(t, "Charger", updated.Task.Checklist[1].Text)
+
+	// Checklist ikut direpresentasikan di task, termasuk setelah cache
+	w = sendJSON(router, "GET", "/api/tasks/1", nil, nil)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated.Task))
+	assert.Len(t, updated.Task.Checklist, 2)
+
+	w = sendJSON(router, "DELETE", "/api/tasks/1/checklist/99", nil, nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "checklist_item_not_found", decodeProblem(t, w).Code)
+	w = sendJSON(router, "DELETE", "/api/tasks/1/checklist/abc", nil, nil)
+	assert.Equal(t, "invalid_checklist_item_id", decodeProblem(t, w).Code)
+
+	w = sendJSON(router, "DELETE", fmt.Sprintf("/api/tasks/1/checklist/%d", added.Item.ID), nil, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &updated))
+	require.Len(t, updated.Task.Checklist, 1)
+	assert.Equal(t, "Charger", updated.Task.Checklist[0].Text)
+}
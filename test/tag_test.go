@@ -0,0 +1,219 @@
+// tests/tag_test.go
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/filter"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/programmercintasunnah/go-todolist-ilcs/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tagNames(task models.Task) []string {
+	names := []string{}
+	for _, tag := range task.Tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+func TestTagService(t *testing.T) {
+	f := setupRepositories(t)
+	ctx := context.Background()
+	svc := services.NewTagService(repositories.NewTagRepository(f.repo))
+
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	var ids []uint
+	for _, title := range []string{"Deploy", "Pager rota", "Budget"} {
+		task := models.Task{Title: title, Status: "pending", DueDate: due}
+		require.NoError(t, f.repo.CreateTask(ctx, &task))
+		assert.Equal(t, []models.Tag{}, task.Tags)
+		ids = append(ids, task.ID)
+	}
+
+	task, err := svc.AttachTags(ctx, ids[0], 1, []string{" Infra", "urgent", "infra"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"infra", "urgent"}, tagNames(*task))
+	assert.Equal(t, uint(2), task.Version)
+	_, err = svc.AttachTags(ctx, ids[1], 0, []string{"infra", "on-call"})
+	require.NoError(t, err)
+
+	// Versi lama ditolak seperti If-Match
+	_, err = svc.AttachTags(ctx, ids[0], 1, []string{"later"})
+	assert.ErrorIs(t, err, apperrors.ErrPreconditionFailed)
+	_, err = svc.AttachTags(ctx, ids[0], 0, []string{"a,b"})
+	assert.ErrorIs(t, err, apperrors.ErrValidation)
+
+	// Tag ikut dimuat di daftar task tanpa query per task
+	page, err := f.repo.GetAllTasks(ctx, nil, repositories.Pagination{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, page.Tasks, 3)
+	assert.Equal(t, []string{"infra", "urgent"}, tagNames(page.Tasks[0]))
+	assert.Equal(t, []string{"infra", "on-call"}, tagNames(page.Tasks[1]))
+	assert.Equal(t, []string{}, tagNames(page.Tasks[2]))
+
+	where := func(q string) []uint {
+		e, err := filter.Parse(q)
+		require.NoError(t, err)
+		page, err := f.repo.GetAllTasks(ctx, e, repositories.Pagination{Page: 1, Limit: 10})
+		require.NoError(t, err)
+		return taskIDs(page.Tasks)
+	}
+	assert.Equal(t, []uint{ids[0], ids[1]}, where("tag:infra"))
+	assert.Equal(t, []uint{ids[0]}, where("tag:infra tag:urgent"))
+	assert.Equal(t, []uint{ids[2]}, where("-tag:infra"))
+
+	tags, err := svc.ListTags(ctx)
+	require.NoError(t, err)
+	require.Len(t, tags, 3)
+	assert.Equal(t, "infra", tags[0].Name)
+	assert.Equal(t, 2, tags[0].TaskCount)
+	infra, onCall, urgent := tags[0].ID, tags[1].ID, tags[2].ID
+
+	err = svc.CreateTag(ctx, &models.Tag{Name: "URGENT"})
+	assert.ErrorIs(t, err, apperrors.ErrConflict)
+
+	// Rename mengubah representasi task sehingga cache dan versinya berubah
+	cached, err := f.repo.GetTaskByID(ctx, ids[0])
+	require.NoError(t, err)
+	require.NoError(t, svc.RenameTag(ctx, &models.Tag{ID: infra, Name: "Platform"}))
+	renamed, err := f.repo.GetTaskByID(ctx, ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, []string{"platform", "urgent"}, tagNames(*renamed))
+	assert.Equal(t, cached.Version+1, renamed.Version)
+
+	merged, err := svc.MergeTags(ctx, onCall, urgent)
+	require.NoError(t, err)
+	assert.Equal(t, 2, merged.TaskCount)
+	assert.Equal(t, []uint{ids[0], ids[1]}, where("tag:urgent"))
+	_, err = svc.GetTag(ctx, onCall)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+	_, err = svc.MergeTags(ctx, urgent, urgent)
+	assert.ErrorIs(t, err, apperrors.ErrValidation)
+
+	task, err = svc.DetachTag(ctx, ids[1], 0, urgent)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"platform"}, tagNames(*task))
+	_, err = svc.DetachTag(ctx, ids[1], 0, urgent)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+	require.NoError(t, svc.DeleteTag(ctx, infra))
+	assert.Empty(t, where("tag:platform"))
+	task, err = f.repo.GetTaskByID(ctx, ids[1])
+	require.NoError(t, err)
+	assert.Equal(t, []string{}, tagNames(*task))
+}
+
+func TestGetAllTasksByTags(t *testing.T) {
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	router := SetupRouter(
+		models.Task{ID: 1, Title: "Deploy", Status: "pending", DueDate: due, Tags: []models.Tag{{ID: 1, Name: "infra"}, {ID: 2, Name: "urgent"}}},
+		models.Task{ID: 2, Title: "Pager rota", Status: "pending", DueDate: due, Tags: []models.Tag{{ID: 1, Name: "infra"}}},
+		models.Task{ID: 3, Title: "Budget", Status: "pending", DueDate: due, Tags: []models.Tag{{ID: 3, Name: "finance"}}},
+	)
+
+	assert.Equal(t, []uint{1, 2}, listTaskIDs(t, router, "/api/tasks?tags=infra"))
+	assert.Equal(t, []uint{1, 3}, listTaskIDs(t, router, "/api/tasks?tags=Urgent,+finance"))
+	assert.Equal(t, []uint{1}, listTaskIDs(t, router, "/api/tasks?tags=infra,urgent&tag_match=all"))
+
+	w, _ := getList(t, router, "/api/tasks?tags=infra,,urgent")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "invalid_filter", decodeProblem(t, w).Code)
+
+	w, _ = getList(t, router, "/api/tasks?tags=infra&tag_match=some")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "tag_match", decodeProblem(t, w).Errors[0].Field)
+}
+
+func TestAttachTagsReusesConcurrentlyCreatedTag(t *testing.T) {
+	f := setupRepositories(t)
+	ctx := context.Background()
+	repo := repositories.NewTagRepository(f.repo)
+
+	// Trigger meniru transaksi lain yang membuat tag "race" di antara SELECT
+	// dan INSERT milik AttachTags
+	_, err := f.db.Exec(`CREATE TRIGGER tags_race BEFORE INSERT ON tags WHEN NEW.name = 'race'
+		BEGIN INSERT INTO tags (name, created_at) VALUES ('race', CURRENT_TIMESTAMP); END`)
+	require.NoError(t, err)
+
+	task := models.Task{Title: "Deploy", Status: "pending", DueDate: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)}
+	require.NoError(t, f.repo.CreateTask(ctx, &task))
+	updated, err := repo.AttachTags(ctx, task.ID, 0, []string{"race"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"race"}, tagNames(*updated))
+
+	tags, err := repo.ListTags(ctx)
+	require.NoError(t, err)
+	require.Len(t, tags, 1)
+	assert.Equal(t, tags[0].ID, updated.Tags[0].ID)
+
+	_, err = f.db.Exec(`DROP TRIGGER tags_race`)
+	require.NoError(t, err)
+	err = repo.CreateTag(ctx, &models.Tag{Name: "race"})
+	assert.ErrorIs(t, err, apperrors.ErrConflict)
+}
+
+func TestTagEndpoints(t *testing.T) {
+	router, f := setupRepoRouter(t)
+	task := models.Task{Title: "Deploy", Status: "pending", DueDate: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)}
+	require.NoError(t, f.repo.CreateTask(context.Background(), &task))
+
+	w := sendJSON(router, "POST", "/api/tags", gin.H{"name": "Infra"}, nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/api/tags/1", w.Header().Get("Location"))
+	assert.Equal(t, http.StatusConflict, sendJSON(router, "POST", "/api/tags", gin.H{"name": "infra"}, nil).Code)
+
+	w = sendJSON(router, "POST", "/api/tasks/1/tags", gin.H{"tags": []string{"infra", "urgent"}}, http.Header{"If-Match": {task.ETag()}})
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"1-2"`, w.Header().Get("ETag"))
+	var response struct {
+		Task models.Task `json:"task"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []string{"infra", "urgent"}, tagNames(response.Task))
+
+	// ETag lama tidak berlaku lagi setelah tag berubah
+	assert.Equal(t, http.StatusPreconditionFailed, sendJSON(router, "POST", "/api/tasks/1/tags", gin.H{"tags": []string{"later"}}, http.Header{"If-Match": {task.ETag()}}).Code)
+	assert.Equal(t, http.StatusNotFound, sendJSON(router, "POST", "/api/tasks/9/tags", gin.H{"tags": []string{"later"}}, nil).Code)
+	assert.Equal(t, http.StatusBadRequest, sendJSON(router, "POST", "/api/tasks/1/tags", gin.H{"tags": []string{}}, nil).Code)
+	w = sendJSON(router, "POST", "/api/tasks/-1/tags", gin.H{"tags": []string{"later"}}, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "invalid_task_id", decodeProblem(t, w).Code)
+
+	w = sendJSON(router, "GET", "/api/tags", nil, nil)
+	var list struct {
+		Tags []struct {
+			ID        uint   `json:"id"`
+			Name      string `json:"name"`
+			TaskCount int    `json:"task_count"`
+		} `json:"tags"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Tags, 2)
+	assert.Equal(t, 1, list.Tags[0].TaskCount)
+
+	assert.Equal(t, http.StatusOK, sendJSON(router, "PUT", "/api/tags/1", gin.H{"name": "Platform"}, nil).Code)
+	w = sendJSON(router, "POST", "/api/tags/2/merge", gin.H{"into": 1}, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, http.StatusBadRequest, sendJSON(router, "POST", "/api/tags/1/merge", gin.H{"into": 1}, nil).Code)
+	assert.Equal(t, http.StatusNotFound, sendJSON(router, "GET", "/api/tags/2", nil, nil).Code)
+	assert.Equal(t, http.StatusBadRequest, sendJSON(router, "GET", "/api/tags/abc", nil, nil).Code)
+
+	w = sendJSON(router, "DELETE", "/api/tasks/1/tags/1", nil, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []string{}, tagNames(response.Task))
+	assert.Equal(t, http.StatusNotFound, sendJSON(router, "DELETE", "/api/tasks/1/tags/1", nil, nil).Code)
+
+	assert.Equal(t, http.StatusOK, sendJSON(router, "DELETE", "/api/tags/1", nil, nil).Code)
+	assert.Equal(t, http.StatusNotFound, sendJSON(router, "DELETE", "/api/tags/1", nil, nil).Code)
+}
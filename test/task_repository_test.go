@@ -0,0 +1,94 @@
+// tests/task_repository_test.go
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAllTasksOrderBy(t *testing.T) {
+	f := setupRepositories(t)
+	ctx := context.Background()
+
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	for _, task := range []models.Task{
+		{Title: "b", Status: "pending", DueDate: due, Priority: 1},
+		{Title: "a", Status: "completed", DueDate: due.AddDate(0, 0, 1), Priority: 3},
+		{Title: "c", Status: "pending", DueDate: due, Priority: 3},
+	} {
+		require.NoError(t, f.repo.CreateTask(ctx, &task))
+	}
+
+	ids := func(sort ...repositories.SortField) []uint {
+		page, err := f.repo.GetAllTasks(ctx, nil, repositories.Pagination{Page: 1, Limit: 10, Sort: sort})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), page.Total)
+		return taskIDs(page.Tasks)
+	}
+
+	assert.Equal(t, []uint{1, 2, 3}, ids())
+	assert.Equal(t, []uint{2, 3, 1}, ids(repositories.SortField{Field: "priority", Desc: true}, repositories.SortField{Field: "due_date", Desc: true}))
+	assert.Equal(t, []uint{1, 3, 2}, ids(repositories.SortField{Field: "status", Desc: true}))
+
+	_, err := f.repo.GetAllTasks(ctx, nil, repositories.Pagination{Page: 1, Limit: 10, Sort: []repositories.SortField{{Field: "1; DROP TABLE tasks"}}})
+	assert.Error(t, err)
+}
+
+func taskIDs(tasks []models.Task) []uint {
+	ids := make([]uint, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
+
+func TestGetAllTasksKeyset(t *testing.T) {
+	f := setupRepositories(t)
+	ctx := context.Background()
+
+	// Prioritas berulang agar batas halaman jatuh di antara nilai yang sama
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 7; i++ {
+		task := models.Task{Title: "task", Status: "pending", DueDate: due.AddDate(0, 0, i%2), Priority: i % 3}
+		require.NoError(t, f.repo.CreateTask(ctx, &task))
+	}
+	sort := []repositories.SortField{{Field: "priority", Desc: true}, {Field: "due_date"}}
+
+	all, err := f.repo.GetAllTasks(ctx, nil, repositories.Pagination{Page: 1, Limit: 10, Sort: sort})
+	require.NoError(t, err)
+	want := taskIDs(all.Tasks)
+	require.Len(t, want, 7)
+
+	var got []uint
+	pagination := repositories.Pagination{Limit: 3, Sort: sort, SkipCount: true, Page: 1}
+	var pages []*repositories.TaskPage
+	for {
+		page, err := f.repo.GetAllTasks(ctx, nil, pagination)
+		require.NoError(t, err)
+		assert.Equal(t, int64(-1), page.Total)
+		got = append(got, taskIDs(page.Tasks)...)
+		pages = append(pages, page)
+		if !page.HasNext {
+			break
+		}
+		last := page.Tasks[len(page.Tasks)-1]
+		pagination.After = &last
+	}
+	assert.Equal(t, want, got)
+	assert.Len(t, pages, 3)
+	assert.False(t, pages[0].HasPrev)
+
+	// Mundur dari halaman terakhir menghasilkan halaman kedua lagi
+	first := pages[2].Tasks[0]
+	back, err := f.repo.GetAllTasks(ctx, nil, repositories.Pagination{Limit: 3, Sort: sort, Before: &first})
+	require.NoError(t, err)
+	assert.Equal(t, taskIDs(pages[1].Tasks), taskIDs(back.Tasks))
+	assert.True(t, back.HasPrev)
+	assert.True(t, back.HasNext)
+}
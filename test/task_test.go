@@ -4,40 +4,146 @@ package tests
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/programmercintasunnah/go-todolist-ilcs/controllers"
+	"github.com/programmercintasunnah/go-todolist-ilcs/middlewares"
 	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
 	"github.com/programmercintasunnah/go-todolist-ilcs/services"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func SetupRouter() *gin.Engine {
+func SetupRouter(tasks ...models.Task) *gin.Engine {
 	router := gin.Default()
 	logger := logrus.New()
+	router.Use(middlewares.Locale(), middlewares.ErrorHandler(logger))
 
 	// Mock service
-	mockService := &services.MockTaskService{}
+	mockService := &services.MockTaskService{Tasks: tasks}
+	viewService := &services.MockViewService{}
 
-	taskController := controllers.NewTaskController(mockService, logger)
+	taskController := controllers.NewTaskController(mockService, viewService, "test-cursor-secret", logger)
+	viewController := controllers.NewViewController(viewService, logger)
 
 	protected := router.Group("/api")
 	protected.Use(func(c *gin.Context) {
 		// Mock JWT authentication
+		username := c.GetHeader("X-Test-User")
+		if username == "" {
+			username = "admin"
+		}
+		c.Set("username", username)
 		c.Next()
 	})
 	{
 		protected.POST("/tasks", taskController.CreateTask)
+		protected.GET("/tasks", taskController.GetAllTasks)
+		protected.GET("/tasks/suggest", taskController.SuggestTitles)
+		protected.POST("/tasks/bulk", taskController.BulkTasks)
+		protected.POST("/tasks/bulk/update", taskController.UpdateTasksByFilter)
+		protected.POST("/tasks/bulk/delete", taskController.DeleteTasksByFilter)
+		protected.GET("/tasks/:id", taskController.GetTaskByID)
+		protected.PUT("/tasks/:id", taskController.UpdateTask)
+		protected.PATCH("/tasks/:id", taskController.PatchTask)
+		protected.DELETE("/tasks/:id", taskController.DeleteTask)
+
+		protected.POST("/views", viewController.CreateView)
+		protected.GET("/views", viewController.ListViews)
+		protected.GET("/views/:id", viewController.GetView)
+		protected.PUT("/views/:id", viewController.UpdateView)
+		protected.DELETE("/views/:id", viewController.DeleteView)
+		protected.PUT("/views/:id/default", viewController.SetDefaultView)
+		protected.DELETE("/views/:id/default", viewController.ClearDefaultView)
 	}
 
 	return router
 }
 
+// setupRepoRouter mendaftarkan route task, tag, project, subtask, checklist
+// dan dependency di atas repository SQLite dari setupRepositories.
+func setupRepoRouter(t *testing.T) (*gin.Engine, repoFixture) {
+	f := setupRepositories(t)
+	logger := logrus.New()
+	taskController := controllers.NewTaskController(newSubtaskService(f, 3), &services.MockViewService{}, "test-cursor-secret", logger)
+	tagController := controllers.NewTagController(services.NewTagService(repositories.NewTagRepository(f.repo)), logger)
+	projectController := controllers.NewProjectController(services.NewProjectService(repositories.NewProjectRepository(f.repo)), logger)
+	checklistController := controllers.NewChecklistController(services.NewChecklistService(repositories.NewChecklistRepository(f.repo)), logger)
+	dependencyController := controllers.NewDependencyController(services.NewDependencyService(repositories.NewDependencyRepository(f.repo)), logger)
+
+	router := gin.New()
+	router.Use(middlewares.Locale(), middlewares.ErrorHandler(logger))
+	api := router.Group("/api")
+	api.Use(func(c *gin.Context) {
+		username := c.GetHeader("X-Test-User")
+		if username == "" {
+			username = "admin"
+		}
+		c.Set("username", username)
+		c.Next()
+	})
+	{
+		api.POST("/tasks", taskController.CreateTask)
+		api.GET("/tasks", taskController.GetAllTasks)
+		api.GET("/tasks/:id", taskController.GetTaskByID)
+		api.PUT("/tasks/:id", taskController.UpdateTask)
+		api.POST("/tasks/:id/tags", tagController.AttachTags)
+		api.DELETE("/tasks/:id/tags/:tag_id", tagController.DetachTag)
+		api.PUT("/tasks/:id/project", projectController.MoveTask)
+		api.GET("/tasks/:id/subtasks", taskController.GetSubtasks)
+		api.PUT("/tasks/:id/parent", taskController.SetParent)
+		api.POST("/tasks/:id/checklist", checklistController.AddItem)
+		api.PUT("/tasks/:id/checklist/:item_id", checklistController.UpdateItem)
+		api.DELETE("/tasks/:id/checklist/:item_id", checklistController.DeleteItem)
+		api.GET("/tasks/:id/dependencies", dependencyController.GetGraph)
+		api.POST("/tasks/:id/dependencies", dependencyController.LinkTasks)
+		api.DELETE("/tasks/:id/dependencies/:blocker_id", dependencyController.UnlinkTasks)
+
+		api.GET("/tags", tagController.ListTags)
+		api.POST("/tags", tagController.CreateTag)
+		api.GET("/tags/:id", tagController.GetTag)
+		api.PUT("/tags/:id", tagController.RenameTag)
+		api.DELETE("/tags/:id", tagController.DeleteTag)
+		api.POST("/tags/:id/merge", tagController.MergeTags)
+
+		api.GET("/projects", projectController.ListProjects)
+		api.POST("/projects", projectController.CreateProject)
+		api.GET("/projects/:id", projectController.GetProject)
+		api.PUT("/projects/:id", projectController.UpdateProject)
+		api.DELETE("/projects/:id", projectController.DeleteProject)
+		api.PUT("/projects/:id/archive", projectController.ArchiveProject)
+		api.DELETE("/projects/:id/archive", projectController.UnarchiveProject)
+		api.GET("/projects/:id/tasks", projectController.RequireProject, taskController.GetAllTasks)
+		api.POST("/projects/:id/tasks", projectController.RequireProject, taskController.CreateTask)
+	}
+	return router, f
+}
+
+// sendJSON mengirim body sebagai JSON bersama header tambahan.
+func sendJSON(router *gin.Engine, method, url string, body interface{}, header http.Header) *httptest.ResponseRecorder {
+	var payload bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&payload).Encode(body)
+	}
+	req, _ := http.NewRequest(method, url, &payload)
+	req.Header.Set("Content-Type", "application/json")
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
 func TestCreateTask(t *testing.T) {
 	router := SetupRouter()
 
@@ -60,3 +166,483 @@ func TestCreateTask(t *testing.T) {
 	json.Unmarshal(w.Body.Bytes(), &response)
 	assert.Equal(t, "Task created successfully", response["message"])
 }
+
+func TestCreateTaskReturnsCreatedTask(t *testing.T) {
+	router := SetupRouter()
+
+	task := gin.H{
+		"title":       "Test Task",
+		"description": "This is a test task",
+		"status":      "pending",
+		"due_date":    time.Now().Add(24 * time.Hour).Format("2006-01-02"),
+	}
+
+	jsonValue, _ := json.Marshal(task)
+	req, _ := http.NewRequest("POST", "/api/tasks", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response struct {
+		Message string
+		Task    models.Task
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "Task created successfully", response.Message)
+	assert.NotZero(t, response.Task.ID)
+	assert.False(t, response.Task.CreatedAt.IsZero())
+	assert.Equal(t, fmt.Sprintf("/api/tasks/%d", response.Task.ID), w.Header().Get("Location"))
+}
+
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) middlewares.Problem {
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	var problem middlewares.Problem
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	return problem
+}
+
+func TestGetTaskNotFoundProblem(t *testing.T) {
+	router := SetupRouter()
+
+	req, _ := http.NewRequest("GET", "/api/tasks/42", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	problem := decodeProblem(t, w)
+	assert.Equal(t, "task_not_found", problem.Code)
+	assert.Equal(t, http.StatusNotFound, problem.Status)
+	assert.Equal(t, "/api/tasks/42", problem.Instance)
+}
+
+func TestDeleteMissingTaskIsNotFound(t *testing.T) {
+	router := SetupRouter()
+
+	req, _ := http.NewRequest("DELETE", "/api/tasks/42", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "task_not_found", decodeProblem(t, w).Code)
+}
+
+func TestCreateTaskValidationProblem(t *testing.T) {
+	router := SetupRouter()
+
+	jsonValue, _ := json.Marshal(gin.H{"status": "done", "due_date": "tomorrow"})
+	req, _ := http.NewRequest("POST", "/api/tasks", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	problem := decodeProblem(t, w)
+	assert.Equal(t, "validation_failed", problem.Code)
+
+	fields := make(map[string]string)
+	for _, fe := range problem.Errors {
+		fields[fe.Field] = fe.Rule
+	}
+	assert.Equal(t, map[string]string{"title": "required", "status": "oneof", "due_date": "datetime"}, fields)
+}
+
+func TestProblemLocalizedByAcceptLanguage(t *testing.T) {
+	router := SetupRouter()
+
+	jsonValue, _ := json.Marshal(gin.H{"title": "Belajar", "status": "pending"})
+	req, _ := http.NewRequest("POST", "/api/tasks", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "id-ID,id;q=0.9,en;q=0.8")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "id", w.Header().Get("Content-Language"))
+	problem := decodeProblem(t, w)
+	assert.Equal(t, "validation_failed", problem.Code)
+	assert.Equal(t, "Validasi request gagal", problem.Detail)
+	if assert.Len(t, problem.Errors, 1) {
+		assert.Equal(t, "due_date", problem.Errors[0].Field)
+		assert.Equal(t, "wajib diisi", problem.Errors[0].Message)
+	}
+}
+
+func existingTask() models.Task {
+	return models.Task{
+		ID:          7,
+		Title:       "Write report",
+		Description: "Quarterly numbers",
+		Status:      "pending",
+		DueDate:     time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+		Version:     1,
+	}
+}
+
+func sendPatch(router *gin.Engine, contentType, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("PATCH", "/api/tasks/7", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPatchTaskMergePatchClearsField(t *testing.T) {
+	router := SetupRouter(existingTask())
+
+	w := sendPatch(router, "application/merge-patch+json", `{"description": null, "status": "completed"}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct{ Task models.Task }
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, "", response.Task.Description)
+	assert.Equal(t, "completed", response.Task.Status)
+	assert.Equal(t, "Write report", response.Task.Title)
+}
+
+func TestPatchTaskJSONPatch(t *testing.T) {
+	router := SetupRouter(existingTask())
+
+	w := sendPatch(router, "application/json-patch+json", `[
+		{"op": "test", "path": "/status", "value": "pending"},
+		{"op": "replace", "path": "/title", "value": "Write final report"},
+		{"op": "remove", "path": "/description"}
+	]`)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = sendPatch(router, "application/json-patch+json", `[{"op": "test", "path": "/status", "value": "completed"}]`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "patch_test_failed", decodeProblem(t, w).Code)
+
+	w = sendPatch(router, "application/json-patch+json", `[{"op": "add", "path": "/id", "value": 9}]`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "invalid_patch", decodeProblem(t, w).Code)
+
+	w = sendPatch(router, "application/json-patch+json", `[{"op": "remove", "path": "/title"}]`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "validation_failed", decodeProblem(t, w).Code)
+}
+
+func TestPatchTaskUnsupportedMediaType(t *testing.T) {
+	router := SetupRouter(existingTask())
+
+	w := sendPatch(router, "text/plain", `title=x`)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	assert.Contains(t, w.Header().Get("Accept-Patch"), "application/json-patch+json")
+}
+
+func TestUpdateTaskIsFullReplacement(t *testing.T) {
+	router := SetupRouter(existingTask())
+
+	jsonValue, _ := json.Marshal(gin.H{"title": "Write report", "status": "pending"})
+	req, _ := http.NewRequest("PUT", "/api/tasks/7", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "PUT requires every required field")
+
+	jsonValue, _ = json.Marshal(gin.H{"title": "Write report", "status": "pending", "due_date": "2026-11-02"})
+	req, _ = http.NewRequest("PUT", "/api/tasks/7", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct{ Task models.Task }
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, "", response.Task.Description, "omitted description is cleared")
+}
+
+func TestGetTaskETagAndNotModified(t *testing.T) {
+	router := SetupRouter(existingTask())
+
+	req, _ := http.NewRequest("GET", "/api/tasks/7", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"7-1"`, w.Header().Get("ETag"))
+
+	req, _ = http.NewRequest("GET", "/api/tasks/7", nil)
+	req.Header.Set("If-None-Match", `"7-1"`)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestIfMatchRejectsStaleVersion(t *testing.T) {
+	router := SetupRouter(existingTask())
+
+	req, _ := http.NewRequest("PATCH", "/api/tasks/7", bytes.NewBufferString(`{"status": "completed"}`))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req.Header.Set("If-Match", `"7-1"`)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"7-2"`, w.Header().Get("ETag"))
+
+	// Client kedua masih memegang versi 1
+	jsonValue, _ := json.Marshal(gin.H{"title": "Other", "status": "pending", "due_date": "2026-11-02"})
+	req, _ = http.NewRequest("PUT", "/api/tasks/7", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"7-1"`)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	assert.Equal(t, "task_version_mismatch", decodeProblem(t, w).Code)
+
+	req, _ = http.NewRequest("DELETE", "/api/tasks/7", nil)
+	req.Header.Set("If-Match", `W/"7-2"`)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code, "weak ETags never match If-Match")
+
+	req, _ = http.NewRequest("DELETE", "/api/tasks/7", nil)
+	req.Header.Set("If-Match", `"7-2"`)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func sendBulk(router *gin.Engine, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("POST", "/api/tasks/bulk", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestBulkTasksAtomicRollsBack(t *testing.T) {
+	router := SetupRouter(existingTask())
+
+	w := sendBulk(router, `{"operations": [
+		{"op": "create", "title": "New", "status": "pending", "due_date": "2026-11-05"},
+		{"op": "delete", "id": 7},
+		{"op": "update", "id": 99, "title": "Missing", "status": "pending", "due_date": "2026-11-05"}
+	]}`)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	problem := decodeProblem(t, w)
+	assert.Equal(t, "task_not_found", problem.Code)
+	assert.Equal(t, "/api/tasks/bulk#/operations/2", problem.Instance)
+
+	req, _ := http.NewRequest("GET", "/api/tasks/7", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code, "delete must be rolled back")
+}
+
+func TestBulkTasksPartialReportsPerItem(t *testing.T) {
+	router := SetupRouter(existingTask())
+
+	w := sendBulk(router, `{"mode": "partial", "operations": [
+		{"op": "update", "id": 7, "title": "Write report", "status": "completed", "due_date": "2026-11-01"},
+		{"op": "create", "title": "No status", "due_date": "2026-11-05"},
+		{"op": "delete", "id": 99}
+	]}`)
+
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+	var response struct {
+		Succeeded int
+		Failed    int
+		Results   []controllers.BulkItemResult
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Succeeded)
+	assert.Equal(t, 2, response.Failed)
+	if assert.Len(t, response.Results, 3) {
+		assert.Equal(t, http.StatusOK, response.Results[0].Status)
+		assert.Equal(t, "completed", response.Results[0].Task.Status)
+		assert.Equal(t, "validation_failed", response.Results[1].Error.Code)
+		assert.Equal(t, http.StatusNotFound, response.Results[2].Status)
+	}
+}
+
+func TestBulkUpdateByFilterDryRun(t *testing.T) {
+	other := existingTask()
+	other.ID, other.Title, other.Status = 8, "Pay invoice", "completed"
+	router := SetupRouter(existingTask(), other)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/tasks/bulk/update", bytes.NewBufferString(`{"filter": {"status": "pending", "search": "report"}, "set": {"status": "completed"}, "dry_run": true}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		DryRun   bool `json:"dry_run"`
+		Affected int
+		IDs      []uint
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.True(t, response.DryRun)
+	assert.Equal(t, 1, response.Affected)
+	assert.Equal(t, []uint{7}, response.IDs)
+
+	req, _ = http.NewRequest("GET", "/api/tasks/7", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"status":"pending"`, "dry run must not change anything")
+}
+
+func TestBulkDeleteByFilterRequiresFilter(t *testing.T) {
+	router := SetupRouter(existingTask())
+
+	req, _ := http.NewRequest("POST", "/api/tasks/bulk/delete", bytes.NewBufferString(`{"filter": {}}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "empty_filter", decodeProblem(t, w).Code)
+
+	req, _ = http.NewRequest("POST", "/api/tasks/bulk/delete", bytes.NewBufferString(`{"filter": {"due_before": "2026-12-01"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"affected":1`)
+}
+
+func listTaskIDs(t *testing.T, router *gin.Engine, url string) []uint {
+	req, _ := http.NewRequest("GET", url, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Tasks []models.Task `json:"tasks"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	ids := make([]uint, len(response.Tasks))
+	for i, task := range response.Tasks {
+		ids[i] = task.ID
+	}
+	return ids
+}
+
+func TestGetAllTasksSorted(t *testing.T) {
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	router := SetupRouter(
+		models.Task{ID: 1, Title: "b", Status: "pending", DueDate: due, Priority: 1},
+		models.Task{ID: 2, Title: "a", Status: "pending", DueDate: due.AddDate(0, 0, 1), Priority: 3},
+		models.Task{ID: 3, Title: "c", Status: "pending", DueDate: due, Priority: 3},
+	)
+
+	assert.Equal(t, []uint{2, 3, 1}, listTaskIDs(t, router, "/api/tasks?sort=priority:desc,due_date:desc"))
+	assert.Equal(t, []uint{2, 1, 3}, listTaskIDs(t, router, "/api/tasks?sort=title"))
+	// Nilai yang sama diurutkan berdasarkan id
+	assert.Equal(t, []uint{1, 3, 2}, listTaskIDs(t, router, "/api/tasks?sort=due_date"))
+}
+
+func TestGetAllTasksRejectsUnknownSort(t *testing.T) {
+	router := SetupRouter()
+
+	for _, sort := range []string{"description", "title:up", "title,title:desc", "id;DROP TABLE tasks"} {
+		req, _ := http.NewRequest("GET", "/api/tasks?sort="+url.QueryEscape(sort), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, sort)
+		problem := decodeProblem(t, w)
+		if assert.Len(t, problem.Errors, 1, sort) {
+			assert.Equal(t, "sort", problem.Errors[0].Field)
+		}
+	}
+}
+
+type listResponse struct {
+	Tasks      []models.Task          `json:"tasks"`
+	Pagination map[string]interface{} `json:"pagination"`
+}
+
+func getList(t *testing.T, router *gin.Engine, url string) (*httptest.ResponseRecorder, listResponse) {
+	req, _ := http.NewRequest("GET", url, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var response listResponse
+	if w.Code == http.StatusOK {
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	}
+	return w, response
+}
+
+// linkRel mengambil URL untuk rel dari header Link.
+func linkRel(header, rel string) string {
+	for _, part := range strings.Split(header, ", ") {
+		target, params, _ := strings.Cut(part, "; ")
+		if params == `rel="`+rel+`"` {
+			return strings.Trim(target, "<>")
+		}
+	}
+	return ""
+}
+
+func TestGetAllTasksCursorPagination(t *testing.T) {
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	var tasks []models.Task
+	for i := 1; i <= 5; i++ {
+		tasks = append(tasks, models.Task{ID: uint(i), Title: "t", Status: "pending", DueDate: due, Priority: i % 2})
+	}
+	router := SetupRouter(tasks...)
+
+	w, first := getList(t, router, "/api/tasks?limit=2&sort=priority:desc&count=false")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, first.Pagination, "total_tasks")
+	assert.Equal(t, "/api/tasks?count=false&limit=2&page=2&sort=priority%3Adesc", linkRel(w.Header().Get("Link"), "next"))
+	assert.Empty(t, linkRel(w.Header().Get("Link"), "last"), "last is unknown without count")
+
+	var ids []uint
+	for _, task := range first.Tasks {
+		ids = append(ids, task.ID)
+	}
+	next := "/api/tasks?limit=2&sort=priority:desc&count=false&cursor=" + first.Pagination["next_cursor"].(string)
+	var last listResponse
+	for next != "" {
+		w, page := getList(t, router, next)
+		assert.Equal(t, http.StatusOK, w.Code)
+		for _, task := range page.Tasks {
+			ids = append(ids, task.ID)
+		}
+		last = page
+		next = linkRel(w.Header().Get("Link"), "next")
+	}
+	assert.Equal(t, []uint{1, 3, 5, 2, 4}, ids)
+
+	// prev_cursor dari halaman terakhir kembali ke halaman kedua
+	w, prev := getList(t, router, "/api/tasks?limit=2&sort=priority:desc&cursor="+url.QueryEscape(last.Pagination["prev_cursor"].(string)))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []uint{5, 2}, []uint{prev.Tasks[0].ID, prev.Tasks[1].ID})
+	assert.Equal(t, float64(5), prev.Pagination["total_tasks"])
+}
+
+func TestGetAllTasksRejectsInvalidCursor(t *testing.T) {
+	router := SetupRouter(existingTask(), models.Task{ID: 8, Title: "t", Status: "pending", DueDate: time.Now()})
+
+	_, page := getList(t, router, "/api/tasks?limit=1&sort=title")
+	cursor := page.Pagination["next_cursor"].(string)
+
+	for name, url := range map[string]string{
+		"tampered":      "/api/tasks?limit=1&sort=title&cursor=x" + cursor,
+		"other sort":    "/api/tasks?limit=1&sort=priority&cursor=" + cursor,
+		"not a cursor":  "/api/tasks?cursor=abc",
+		"bad signature": "/api/tasks?limit=1&sort=title&cursor=" + strings.Split(cursor, ".")[0] + ".AAAA",
+	} {
+		w, _ := getList(t, router, url)
+		assert.Equal(t, http.StatusBadRequest, w.Code, name)
+		assert.Equal(t, "invalid_cursor", decodeProblem(t, w).Code, name)
+	}
+}
@@ -0,0 +1,162 @@
+// tests/unit_of_work_test.go
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/programmercintasunnah/go-todolist-ilcs/config"
+	"github.com/programmercintasunnah/go-todolist-ilcs/migrations"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+type repoFixture struct {
+	db    *sql.DB
+	redis *miniredis.Miniredis
+	repo  repositories.TaskStore
+	uow   repositories.UnitOfWork
+}
+
+// setupRepositories memakai SQLite dan Redis di memori agar transaksi dan
+// cache diuji dengan implementasi repository yang sebenarnya.
+func setupRepositories(t *testing.T) repoFixture {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", "file:"+filepath.Join(t.TempDir(), "tasks.db")+"?_pragma=busy_timeout(5000)")
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	require.NoError(t, migrations.Up(context.Background(), db, "sqlite"))
+
+	mr := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { redisClient.Close() })
+
+	logger := logrus.New()
+	repo := repositories.NewTaskRepository(db, repositories.SQLite, redisClient, config.NewWatcher(config.Default(), nil, logger), logger)
+	return repoFixture{
+		db:    db,
+		redis: mr,
+		repo:  repo,
+		uow:   repositories.NewUnitOfWork(db, repositories.Repositories{Tasks: repo}),
+	}
+}
+
+// createCachedTask membuat task lalu membacanya sekali agar masuk cache.
+func (f repoFixture) createCachedTask(t *testing.T) *models.Task {
+	t.Helper()
+	ctx := context.Background()
+
+	task := &models.Task{Title: "Original", Status: "pending", DueDate: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)}
+	require.NoError(t, f.repo.CreateTask(ctx, task))
+	require.NotZero(t, task.ID)
+
+	_, err := f.repo.GetTaskByID(ctx, task.ID)
+	require.NoError(t, err)
+	require.True(t, f.redis.Exists(cacheKey(task.ID)))
+	return task
+}
+
+func cacheKey(id uint) string {
+	return fmt.Sprintf("task:%d", id)
+}
+
+func (f repoFixture) dbTitle(t *testing.T, id uint) string {
+	t.Helper()
+	var title string
+	require.NoError(t, f.db.QueryRow("SELECT title FROM tasks WHERE id = ?", id).Scan(&title))
+	return title
+}
+
+func (f repoFixture) cachedTitle(t *testing.T, id uint) string {
+	t.Helper()
+	data, err := f.redis.Get(cacheKey(id))
+	require.NoError(t, err)
+	var task models.Task
+	require.NoError(t, json.Unmarshal([]byte(data), &task))
+	return task.Title
+}
+
+func TestUnitOfWorkRollbackKeepsDatabaseAndCache(t *testing.T) {
+	f := setupRepositories(t)
+	task := f.createCachedTask(t)
+	ctx := context.Background()
+
+	boom := errors.New("boom")
+	err := f.uow.Do(ctx, func(repos repositories.Repositories) error {
+		current, err := repos.Tasks.GetTaskByID(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+		current.Title = "Changed"
+		if err := repos.Tasks.UpdateTask(ctx, current); err != nil {
+			return err
+		}
+		if err := repos.Tasks.CreateTask(ctx, &models.Task{Title: "Extra", Status: "pending", DueDate: task.DueDate}); err != nil {
+			return err
+		}
+		return boom
+	})
+	assert.ErrorIs(t, err, boom)
+
+	assert.Equal(t, "Original", f.dbTitle(t, task.ID))
+	assert.Equal(t, "Original", f.cachedTitle(t, task.ID), "cache must still match the database")
+	var count int
+	require.NoError(t, f.db.QueryRow("SELECT COUNT(*) FROM tasks").Scan(&count))
+	assert.Equal(t, 1, count, "insert inside the unit of work must be rolled back")
+}
+
+func TestUnitOfWorkInvalidatesCacheAfterCommit(t *testing.T) {
+	f := setupRepositories(t)
+	task := f.createCachedTask(t)
+	ctx := context.Background()
+
+	err := f.uow.Do(ctx, func(repos repositories.Repositories) error {
+		current, err := repos.Tasks.GetTaskByID(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+		current.Title = "Changed"
+		if err := repos.Tasks.UpdateTask(ctx, current); err != nil {
+			return err
+		}
+		assert.True(t, f.redis.Exists(cacheKey(task.ID)), "cache is only invalidated after commit")
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.False(t, f.redis.Exists(cacheKey(task.ID)))
+	reloaded, err := f.repo.GetTaskByID(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Changed", reloaded.Title)
+	assert.Equal(t, uint(2), reloaded.Version)
+}
+
+func TestBulkWriteAtomicRollsBackDatabase(t *testing.T) {
+	f := setupRepositories(t)
+	task := f.createCachedTask(t)
+	ctx := context.Background()
+
+	_, err := f.repo.BulkWrite(ctx, []repositories.BulkOperation{
+		{Op: repositories.BulkDelete, Task: models.Task{ID: task.ID}},
+		{Op: repositories.BulkUpdate, Task: models.Task{ID: 999, Title: "Missing", Status: "pending", DueDate: task.DueDate}},
+	}, true, nil)
+
+	var bulkErr *repositories.BulkError
+	require.ErrorAs(t, err, &bulkErr)
+	assert.Equal(t, 1, bulkErr.Index)
+	assert.Equal(t, "Original", f.dbTitle(t, task.ID), "delete must be rolled back")
+	assert.True(t, f.redis.Exists(cacheKey(task.ID)))
+}
@@ -0,0 +1,142 @@
+// tests/view_test.go
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
+	"github.com/programmercintasunnah/go-todolist-ilcs/models"
+	"github.com/programmercintasunnah/go-todolist-ilcs/repositories"
+	"github.com/programmercintasunnah/go-todolist-ilcs/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViewService(t *testing.T) {
+	f := setupRepositories(t)
+	ctx := context.Background()
+	svc := services.NewViewService(repositories.NewViewRepository(f.db, repositories.SQLite))
+
+	view := &models.View{Name: "Urgent", Filter: "priority>=2", Sort: "priority:desc", PageSize: 5, SharedWith: []string{" bob ", "bob", "alice"}}
+	require.NoError(t, svc.CreateView(ctx, "alice", view))
+	assert.Equal(t, []string{"bob"}, view.SharedWith)
+
+	err := svc.CreateView(ctx, "alice", &models.View{Name: "Urgent"})
+	assert.ErrorIs(t, err, apperrors.ErrConflict)
+	// Nama yang sama boleh dipakai owner lain
+	require.NoError(t, svc.CreateView(ctx, "bob", &models.View{Name: "Urgent"}))
+
+	shared, err := svc.GetView(ctx, "bob", view.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "priority>=2", shared.Filter)
+	assert.Equal(t, 5, shared.PageSize)
+
+	_, err = svc.GetView(ctx, "carol", view.ID)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+
+	err = svc.UpdateView(ctx, "bob", &models.View{ID: view.ID, Name: "Mine now"})
+	assert.ErrorIs(t, err, apperrors.ErrForbidden)
+
+	views, err := svc.ListViews(ctx, "bob")
+	require.NoError(t, err)
+	require.Len(t, views, 2)
+
+	// Penerima bisa menjadikan view bersama sebagai view bawaan
+	require.NoError(t, svc.SetDefaultView(ctx, "bob", view.ID))
+	def, err := svc.DefaultView(ctx, "bob")
+	require.NoError(t, err)
+	require.NotNil(t, def)
+	assert.Equal(t, view.ID, def.ID)
+	assert.True(t, def.IsDefault)
+
+	// Mencabut bagian juga melepas view bawaan penerima
+	view.SharedWith = nil
+	require.NoError(t, svc.UpdateView(ctx, "alice", view))
+	def, err = svc.DefaultView(ctx, "bob")
+	require.NoError(t, err)
+	assert.Nil(t, def)
+
+	require.NoError(t, svc.SetDefaultView(ctx, "alice", view.ID))
+	require.NoError(t, svc.DeleteView(ctx, "alice", view.ID))
+	def, err = svc.DefaultView(ctx, "alice")
+	require.NoError(t, err)
+	assert.Nil(t, def)
+	_, err = svc.GetView(ctx, "alice", view.ID)
+	assert.ErrorIs(t, err, apperrors.ErrNotFound)
+}
+
+func TestSavedViews(t *testing.T) {
+	due := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	router := SetupRouter(
+		models.Task{ID: 1, Title: "Low", Status: "pending", DueDate: due, Priority: 1},
+		models.Task{ID: 2, Title: "High", Status: "pending", DueDate: due, Priority: 3},
+		models.Task{ID: 3, Title: "Medium", Status: "pending", DueDate: due, Priority: 2},
+		models.Task{ID: 4, Title: "Done", Status: "completed", DueDate: due, Priority: 3},
+	)
+
+	w := sendJSON(router, "POST", "/api/views", gin.H{"name": "Urgent", "filter": "priority>=2 status:pending", "sort": "priority:desc", "page_size": 1, "shared_with": []string{"bob"}}, nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/api/views/1", w.Header().Get("Location"))
+
+	req, _ := http.NewRequest("GET", "/api/tasks?view=1", nil)
+	req.Header.Set("X-Test-User", "bob")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Tasks []models.Task `json:"tasks"`
+		View  struct {
+			ID   uint   `json:"id"`
+			Name string `json:"name"`
+		} `json:"view"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []uint{2}, taskIDs(response.Tasks))
+	assert.Equal(t, "Urgent", response.View.Name)
+
+	// Parameter eksplisit menimpa sort dan limit view, q digabung dengan AND
+	assert.Equal(t, []uint{3, 2}, listTaskIDs(t, router, "/api/tasks?view=1&sort=priority:asc&limit=10"))
+	assert.Equal(t, []uint{3}, listTaskIDs(t, router, "/api/tasks?view=1&limit=10&q=priority:2"))
+
+	// View bawaan dipakai jika parameter view tidak ada
+	assert.Equal(t, http.StatusNoContent, sendJSON(router, "PUT", "/api/views/1/default", nil, nil).Code)
+	w = sendJSON(router, "POST", "/api/views", gin.H{"name": "Completed", "filter": "status:completed", "default": true}, nil)
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, []uint{4}, listTaskIDs(t, router, "/api/tasks"))
+	assert.Equal(t, []uint{1, 2, 3, 4}, listTaskIDs(t, router, "/api/tasks?view=none"))
+	assert.Equal(t, http.StatusNoContent, sendJSON(router, "DELETE", "/api/views/2/default", nil, nil).Code)
+	assert.Equal(t, []uint{1, 2, 3, 4}, listTaskIDs(t, router, "/api/tasks"))
+
+	w, _ = getList(t, router, "/api/tasks?view=abc")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "invalid_view_id", decodeProblem(t, w).Code)
+
+	w = sendJSON(router, "POST", "/api/views", gin.H{"name": "Broken", "filter": "priority>>1"}, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "invalid_filter", decodeProblem(t, w).Code)
+
+	w = sendJSON(router, "POST", "/api/views", gin.H{"name": "Urgent"}, nil)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	assert.Equal(t, http.StatusForbidden, sendJSON(router, "PUT", "/api/views/1", gin.H{"name": "Renamed"}, http.Header{"X-Test-User": {"bob"}}).Code)
+	assert.Equal(t, http.StatusNotFound, sendJSON(router, "GET", "/api/views/1", nil, http.Header{"X-Test-User": {"carol"}}).Code)
+	assert.Equal(t, http.StatusNotFound, sendJSON(router, "GET", "/api/tasks?view=1", nil, http.Header{"X-Test-User": {"carol"}}).Code)
+
+	w = sendJSON(router, "GET", "/api/views", nil, http.Header{"X-Test-User": {"bob"}})
+	var list struct {
+		Views []models.View `json:"views"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Views, 1)
+	assert.Equal(t, "admin", list.Views[0].Owner)
+	assert.False(t, list.Views[0].IsDefault)
+
+	assert.Equal(t, http.StatusOK, sendJSON(router, "DELETE", "/api/views/1", nil, nil).Code)
+	assert.Equal(t, http.StatusNotFound, sendJSON(router, "GET", "/api/views/1", nil, nil).Code)
+}
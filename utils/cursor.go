@@ -0,0 +1,54 @@
+// utils/cursor.go
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// SignCursor menyandikan payload menjadi token cursor opaque berbentuk
+// base64url(JSON) "." base64url(HMAC-SHA256), sehingga client tidak bisa
+// mengubah posisi di dalamnya.
+func SignCursor(payload interface{}, secret string) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	body := base64.RawURLEncoding.EncodeToString(data)
+	return body + "." + base64.RawURLEncoding.EncodeToString(cursorMAC(body, secret)), nil
+}
+
+// ParseCursor memverifikasi tanda tangan token lalu mengisi payload.
+func ParseCursor(token string, secret string, payload interface{}) error {
+	body, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return ErrInvalidCursor
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, cursorMAC(body, secret)) {
+		return ErrInvalidCursor
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, payload); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+// cursorMAC memakai prefix tersendiri agar secret yang sama dengan JWT
+// tidak menghasilkan tanda tangan yang bisa dipertukarkan.
+func cursorMAC(body string, secret string) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte("cursor:" + body))
+	return h.Sum(nil)
+}
@@ -6,18 +6,19 @@ import (
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
-	"github.com/programmercintasunnah/go-todolist-ilcs/config"
 )
 
 type Claims struct {
 	Username string `json:"username"`
+	Locale   string `json:"locale,omitempty"`
 	jwt.StandardClaims
 }
 
-func GenerateJWT(username string, duration time.Duration) (string, error) {
+func GenerateJWT(username string, locale string, secret string, duration time.Duration) (string, error) {
 	expirationTime := time.Now().Add(duration)
 	claims := &Claims{
 		Username: username,
+		Locale:   locale,
 		StandardClaims: jwt.StandardClaims{
 			ExpiresAt: expirationTime.Unix(),
 			IssuedAt:  time.Now().Unix(),
@@ -25,7 +26,7 @@ func GenerateJWT(username string, duration time.Duration) (string, error) {
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(config.LoadConfig().JWTSecret))
+	tokenString, err := token.SignedString([]byte(secret))
 	if err != nil {
 		return "", err
 	}
@@ -2,11 +2,97 @@
 package utils
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"github.com/programmercintasunnah/go-todolist-ilcs/apperrors"
 )
 
 var Validate *validator.Validate
 
 func init() {
 	Validate = validator.New()
+	Validate.RegisterTagNameFunc(fieldName)
+
+	// Validator bawaan gin dipakai oleh ShouldBindJSON/ShouldBindQuery
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(fieldName)
+	}
+}
+
+// fieldName memakai nama di tag json/form agar error menyebut field
+// seperti yang dikirim client (due_date), bukan nama struct Go (DueDate).
+func fieldName(f reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name := strings.Split(f.Tag.Get(tag), ",")[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+// BindingError mengubah error dari ShouldBind* atau Validate menjadi error
+// validasi dengan detail per field, tanpa meneruskan pesan mentah validator.
+func BindingError(err error) *apperrors.Error {
+	var validationErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+
+	switch {
+	case errors.As(err, &validationErrs):
+		fields := make([]apperrors.FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields = append(fields, apperrors.FieldError{
+				Field:   fe.Field(),
+				Rule:    fe.Tag(),
+				Param:   fe.Param(),
+				Message: FieldMessage(fe.Tag(), fe.Param()),
+			})
+		}
+		return apperrors.Validation("validation_failed", "Request validation failed", fields...).Wrap(err)
+	case errors.As(err, &typeErr):
+		return apperrors.Validation("invalid_field_type", "Request body has a field of the wrong type", apperrors.FieldError{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Param:   typeErr.Type.String(),
+			Message: FieldMessage("type", typeErr.Type.String()),
+		}).Wrap(err)
+	case errors.As(err, &syntaxErr), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return apperrors.Validation("malformed_body", "Request body is not valid JSON").Wrap(err)
+	}
+	return apperrors.Validation("invalid_request", "Request could not be parsed").Wrap(err)
+}
+
+// FieldMessage menyusun pesan singkat untuk satu aturan validasi.
+func FieldMessage(rule, param string) string {
+	switch rule {
+	case "required":
+		return "is required"
+	case "oneof":
+		return "must be one of: " + param
+	case "datetime":
+		return "must be a date in the format " + param
+	case "min":
+		return "must be at least " + param
+	case "max":
+		return "must be at most " + param
+	case "type":
+		return "must be of type " + param
+	case "excluded_with":
+		return "cannot be combined with " + param
+	case "excludesall":
+		return "must not contain any of: " + param
+	case "hexcolor":
+		return "must be a hex color, e.g. #1e90ff"
+	}
+	return "is invalid"
 }